@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"sort"
+
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/redis/go-redis/v9"
+)
+
+/*
+Autocomplete terms are kept in two sorted sets:
+*autocomplete-terms - every term with a score of 0, so that prefixes can be looked up in lexicographical order
+*autocomplete-scores - the popularity of every term, to rank the terms sharing a prefix
+*/
+const (
+	autocompleteTermsKey      = "autocomplete-terms"
+	autocompleteScoresKey     = "autocomplete-scores"
+	autocompleteNextTermsKey  = "autocomplete-terms-next"
+	autocompleteNextScoresKey = "autocomplete-scores-next"
+
+	autocompleteScanSize  = 100  //* terms sharing the prefix read before ranking
+	autocompleteBatchSize = 1000 //* terms added per command while rebuilding
+)
+
+// ReplaceAutocompleteTerms rebuilds the terms with their popularity, terms which are not given anymore are dropped.
+// The new sets are written under other keys and renamed over the old ones, so lookups never see a half built set.
+func ReplaceAutocompleteTerms(terms map[string]float64) error {
+	pipe := initializers.RedisClient.Pipeline()
+	pipe.Del(ctx, autocompleteNextTermsKey, autocompleteNextScoresKey)
+
+	lexMembers := make([]redis.Z, 0, autocompleteBatchSize)
+	scoreMembers := make([]redis.Z, 0, autocompleteBatchSize)
+	flush := func() {
+		if len(lexMembers) == 0 {
+			return
+		}
+		pipe.ZAdd(ctx, autocompleteNextTermsKey, lexMembers...)
+		pipe.ZAdd(ctx, autocompleteNextScoresKey, scoreMembers...)
+		lexMembers = lexMembers[:0]
+		scoreMembers = scoreMembers[:0]
+	}
+
+	for term, score := range terms {
+		lexMembers = append(lexMembers, redis.Z{Score: 0, Member: term})
+		scoreMembers = append(scoreMembers, redis.Z{Score: score, Member: term})
+		if len(lexMembers) == autocompleteBatchSize {
+			flush()
+		}
+	}
+	flush()
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		go helpers.LogServerError("Error Setting Autocomplete Terms", err, "")
+		return err
+	}
+
+	swap := initializers.RedisClient.TxPipeline()
+	if len(terms) == 0 {
+		swap.Del(ctx, autocompleteTermsKey, autocompleteScoresKey)
+	} else {
+		swap.Rename(ctx, autocompleteNextTermsKey, autocompleteTermsKey)
+		swap.Rename(ctx, autocompleteNextScoresKey, autocompleteScoresKey)
+	}
+	if _, err := swap.Exec(ctx); err != nil {
+		go helpers.LogServerError("Error Replacing Autocomplete Terms", err, "")
+		return err
+	}
+	return nil
+}
+
+func IncrementAutocompleteTerm(term string) error {
+	pipe := initializers.RedisClient.Pipeline()
+	pipe.ZAdd(ctx, autocompleteTermsKey, redis.Z{Score: 0, Member: term})
+	pipe.ZIncrBy(ctx, autocompleteScoresKey, 1, term)
+	if _, err := pipe.Exec(ctx); err != nil {
+		go helpers.LogServerError("Error Incrementing Autocomplete Term", err, "")
+		return err
+	}
+	return nil
+}
+
+func RemoveAutocompleteTerms(terms ...string) error {
+	if len(terms) == 0 {
+		return nil
+	}
+
+	members := make([]interface{}, len(terms))
+	for i, term := range terms {
+		members[i] = term
+	}
+
+	pipe := initializers.RedisClient.Pipeline()
+	pipe.ZRem(ctx, autocompleteTermsKey, members...)
+	pipe.ZRem(ctx, autocompleteScoresKey, members...)
+	if _, err := pipe.Exec(ctx); err != nil {
+		go helpers.LogServerError("Error Removing Autocomplete Terms", err, "")
+		return err
+	}
+	return nil
+}
+
+// GetAutocompleteSuggestions returns the most popular terms starting with prefix.
+func GetAutocompleteSuggestions(prefix string, limit int) ([]string, error) {
+	terms, err := initializers.RedisClient.ZRangeByLex(ctx, autocompleteTermsKey, &redis.ZRangeBy{
+		Min:   "[" + prefix,
+		Max:   "[" + prefix + "\xff",
+		Count: autocompleteScanSize,
+	}).Result()
+	if err != nil {
+		go helpers.LogServerError("Error Getting Autocomplete Suggestions", err, "")
+		return nil, err
+	}
+
+	if len(terms) == 0 {
+		return []string{}, nil
+	}
+
+	scores, err := initializers.RedisClient.ZMScore(ctx, autocompleteScoresKey, terms...).Result()
+	if err != nil {
+		go helpers.LogServerError("Error Getting Autocomplete Scores", err, "")
+		return nil, err
+	}
+
+	indices := make([]int, len(terms))
+	for i := range indices {
+		indices[i] = i
+	}
+	sort.SliceStable(indices, func(a, b int) bool {
+		return scores[indices[a]] > scores[indices[b]]
+	})
+
+	if len(indices) > limit {
+		indices = indices[:limit]
+	}
+
+	suggestions := make([]string, len(indices))
+	for i, index := range indices {
+		suggestions[i] = terms[index]
+	}
+	return suggestions, nil
+}
@@ -0,0 +1,22 @@
+package cache
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/utils/feed"
+)
+
+func feedKey(userID string, anchor time.Time) string {
+	return fmt.Sprintf("feed-%s-%d", userID, anchor.UnixNano())
+}
+
+func GetFeed(userID string, anchor time.Time) ([]feed.Item, error) {
+	var items []feed.Item
+	err := GetFromCacheGeneric(feedKey(userID, anchor), &items)
+	return items, err
+}
+
+func SetFeed(userID string, anchor time.Time, items []feed.Item) error {
+	return SetToCacheGeneric(feedKey(userID, anchor), items)
+}
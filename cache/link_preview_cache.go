@@ -0,0 +1,23 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/Pratham-Mishra04/interact/utils/unfurl"
+)
+
+func linkPreviewKey(url string) string {
+	hash := sha256.Sum256([]byte(url))
+	return "preview-" + hex.EncodeToString(hash[:])
+}
+
+func GetLinkPreview(url string) (*unfurl.Preview, error) {
+	var preview unfurl.Preview
+	err := GetFromCacheGeneric(linkPreviewKey(url), &preview)
+	return &preview, err
+}
+
+func SetLinkPreview(url string, preview *unfurl.Preview) error {
+	return SetToCacheGeneric(linkPreviewKey(url), preview)
+}
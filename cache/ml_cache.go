@@ -0,0 +1,11 @@
+package cache
+
+func GetMLRecommendations(key string) ([]string, error) {
+	var recommendations []string
+	err := GetFromCacheGeneric("ml-"+key, &recommendations)
+	return recommendations, err
+}
+
+func SetMLRecommendations(key string, recommendations []string) error {
+	return SetToCacheGeneric("ml-"+key, recommendations)
+}
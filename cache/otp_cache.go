@@ -26,5 +26,30 @@ func SetOtpToCache(key string, data []byte) error {
 		helpers.LogServerError("Error setting OTP to cache", err, "")
 		return fmt.Errorf("error setting OTP to cache")
 	}
+	initializers.RedisClient.Del(ctx, "otp-attempts-"+key) //* a new otp gets its own attempts
 	return nil
-}
\ No newline at end of file
+}
+
+func RemoveOtpFromCache(key string) error {
+	if err := initializers.RedisClient.Del(ctx, "otp-"+key, "otp-attempts-"+key).Err(); err != nil {
+		helpers.LogServerError("Error removing OTP from cache", err, "")
+		return fmt.Errorf("error removing OTP from cache")
+	}
+	return nil
+}
+
+// IncrementOtpAttempts counts a wrong code for the otp of key, and removes the otp once it has had too many.
+func IncrementOtpAttempts(key string) error {
+	attempts, err := initializers.RedisClient.Incr(ctx, "otp-attempts-"+key).Result()
+	if err != nil {
+		helpers.LogServerError("Error incrementing OTP attempts", err, "")
+		return fmt.Errorf("error incrementing OTP attempts")
+	}
+	if attempts == 1 {
+		initializers.RedisClient.Expire(ctx, "otp-attempts-"+key, config.VERIFICATION_OTP_EXPIRATION_TIME)
+	}
+	if attempts >= config.VERIFICATION_OTP_MAX_ATTEMPTS {
+		return RemoveOtpFromCache(key)
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+package cache
+
+func GetRecommendations(endpoint string, id string) ([]string, error) {
+	var recommendations []string
+	err := GetFromCacheGeneric("recommendations-"+endpoint+"-"+id, &recommendations)
+	return recommendations, err
+}
+
+func SetRecommendations(endpoint string, id string, recommendations []string) error {
+	return SetToCacheGeneric("recommendations-"+endpoint+"-"+id, recommendations)
+}
@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/redis/go-redis/v9"
+)
+
+/*
+Trending searches are kept in a sorted set with exponentially decaying scores.
+Instead of decaying every score as time passes, a search made at time t adds 2^((t - epoch) / half life),
+so newer searches weigh more. DecayTrendingSearches moves the epoch forward and scales the scores down
+to keep them small.
+*/
+const (
+	trendingSearchesKey      = "trending-searches"
+	trendingSearchesEpochKey = "trending-searches-epoch"
+	trendingSearchesMinScore = 0.01 //* searches which decayed below this are dropped
+)
+
+func getTrendingSearchesEpoch() (time.Time, error) {
+	if err := initializers.RedisClient.SetNX(ctx, trendingSearchesEpochKey, time.Now().Unix(), 0).Err(); err != nil {
+		return time.Time{}, err
+	}
+
+	epoch, err := initializers.RedisClient.Get(ctx, trendingSearchesEpochKey).Int64()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(epoch, 0), nil
+}
+
+func trendingSearchWeight(at, epoch time.Time) float64 {
+	return math.Exp2(at.Sub(epoch).Hours() / config.TRENDING_SEARCH_HALF_LIFE_HOURS)
+}
+
+func IncrementTrendingSearch(query string) error {
+	epoch, err := getTrendingSearchesEpoch()
+	if err != nil {
+		go helpers.LogServerError("Error Getting Trending Searches Epoch", err, "")
+		return err
+	}
+
+	if err := initializers.RedisClient.ZIncrBy(ctx, trendingSearchesKey, trendingSearchWeight(time.Now(), epoch), query).Err(); err != nil {
+		go helpers.LogServerError("Error Incrementing Trending Search", err, "")
+		return err
+	}
+	return nil
+}
+
+// GetTrendingSearches returns the count most trending searches.
+func GetTrendingSearches(count int) ([]string, error) {
+	searches, err := initializers.RedisClient.ZRevRange(ctx, trendingSearchesKey, 0, int64(count-1)).Result()
+	if err != nil {
+		go helpers.LogServerError("Error Getting Trending Searches", err, "")
+		return nil, err
+	}
+	return searches, nil
+}
+
+func HasTrendingSearches() (bool, error) {
+	count, err := initializers.RedisClient.ZCard(ctx, trendingSearchesKey).Result()
+	if err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// SetTrendingSearches replaces the trending searches with scores decayed to the current time.
+func SetTrendingSearches(scores map[string]float64) error {
+	members := make([]redis.Z, 0, len(scores))
+	for query, score := range scores {
+		members = append(members, redis.Z{Score: score, Member: query})
+	}
+
+	pipe := initializers.RedisClient.TxPipeline()
+	pipe.Del(ctx, trendingSearchesKey)
+	if len(members) > 0 {
+		pipe.ZAdd(ctx, trendingSearchesKey, members...)
+	}
+	pipe.Set(ctx, trendingSearchesEpochKey, time.Now().Unix(), 0)
+	if _, err := pipe.Exec(ctx); err != nil {
+		go helpers.LogServerError("Error Setting Trending Searches", err, "")
+		return err
+	}
+	return nil
+}
+
+// DecayTrendingSearches moves the epoch to the last whole half life and drops the searches which are no longer trending.
+func DecayTrendingSearches() error {
+	epoch, err := getTrendingSearchesEpoch()
+	if err != nil {
+		go helpers.LogServerError("Error Getting Trending Searches Epoch", err, "")
+		return err
+	}
+
+	halvings := math.Floor(time.Since(epoch).Hours() / config.TRENDING_SEARCH_HALF_LIFE_HOURS)
+	if halvings < 1 {
+		return nil
+	}
+	newEpoch := epoch.Add(time.Duration(halvings * config.TRENDING_SEARCH_HALF_LIFE_HOURS * float64(time.Hour)))
+
+	pipe := initializers.RedisClient.TxPipeline()
+	pipe.ZUnionStore(ctx, trendingSearchesKey, &redis.ZStore{
+		Keys:    []string{trendingSearchesKey},
+		Weights: []float64{math.Exp2(-halvings)},
+	})
+	pipe.ZRemRangeByScore(ctx, trendingSearchesKey, "-inf", fmt.Sprintf("(%f", trendingSearchesMinScore))
+	pipe.Set(ctx, trendingSearchesEpochKey, newEpoch.Unix(), 0)
+	if _, err := pipe.Exec(ctx); err != nil {
+		go helpers.LogServerError("Error Decaying Trending Searches", err, "")
+		return err
+	}
+	return nil
+}
+
+// MarkSearched reports whether this is the first time the searcher searched the query within the dedupe window.
+func MarkSearched(searcher, query string) (bool, error) {
+	hash := sha256.Sum256([]byte(strings.ToLower(query)))
+	key := "searched-" + searcher + "-" + hex.EncodeToString(hash[:])
+
+	first, err := initializers.RedisClient.SetNX(ctx, key, 1, config.SEARCH_DEDUPE_WINDOW).Result()
+	if err != nil {
+		go helpers.LogServerError("Error Marking Search", err, "")
+		return false, err
+	}
+	return first, nil
+}
+
+func recentSearchesKey(userID string) string {
+	return "recent-searches-" + userID
+}
+
+// AddRecentSearch moves the query to the top of the user's recent searches.
+func AddRecentSearch(userID, query string) error {
+	key := recentSearchesKey(userID)
+
+	pipe := initializers.RedisClient.TxPipeline()
+	pipe.LRem(ctx, key, 0, query)
+	pipe.LPush(ctx, key, query)
+	pipe.LTrim(ctx, key, 0, config.RECENT_SEARCHES_LIMIT-1)
+	pipe.Expire(ctx, key, config.RECENT_SEARCHES_TTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		go helpers.LogServerError("Error Adding Recent Search", err, "")
+		return err
+	}
+	return nil
+}
+
+func GetRecentSearches(userID string) ([]string, error) {
+	searches, err := initializers.RedisClient.LRange(ctx, recentSearchesKey(userID), 0, -1).Result()
+	if err != nil {
+		go helpers.LogServerError("Error Getting Recent Searches", err, "")
+		return nil, err
+	}
+	return searches, nil
+}
+
+func RemoveRecentSearch(userID, query string) error {
+	if err := initializers.RedisClient.LRem(ctx, recentSearchesKey(userID), 0, query).Err(); err != nil {
+		go helpers.LogServerError("Error Removing Recent Search", err, "")
+		return err
+	}
+	return nil
+}
+
+func ClearRecentSearches(userID string) error {
+	if err := initializers.RedisClient.Del(ctx, recentSearchesKey(userID)).Err(); err != nil {
+		go helpers.LogServerError("Error Clearing Recent Searches", err, "")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,5 @@
+package config
+
+const (
+	MAX_COMMENT_DEPTH = 3 //* replies to comments at this depth are added to their thread instead
+)
@@ -0,0 +1,9 @@
+package config
+
+const (
+	FEED_CANDIDATE_WINDOW_DAYS   = 14 //* only items created in this window are ranked
+	FEED_MAX_POST_CANDIDATES     = 300
+	FEED_MAX_CARD_CANDIDATES     = 40 //* per type of card (projects, events, openings)
+	FEED_AFFINITY_WINDOW_DAYS    = 90
+	FEED_RECENCY_HALF_LIFE_HOURS = 18.0
+)
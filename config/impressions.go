@@ -0,0 +1,5 @@
+package config
+
+const (
+	IMPRESSIONS_FLUSH_COUNT = 10 //* impressions kept in the cache before they are added to the item in the database
+)
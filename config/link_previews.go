@@ -0,0 +1,5 @@
+package config
+
+const (
+	MAX_LINK_PREVIEWS = 3 //* previews stored per post or message, for the first links in its content
+)
@@ -1,5 +1,7 @@
 package config
 
+import "github.com/Pratham-Mishra04/interact/initializers"
+
 const (
 	PROJECT_RECOMMENDATION = "/projects/recommend"
 	PROJECT_SIMILAR        = "/projects/similar"
@@ -9,4 +11,21 @@ const (
 	EVENT_RECOMMENDATION   = "/events/recommend"
 	EVENT_SIMILAR          = "/events/similar"
 	IMAGE_BLUR_HASH        = "/image_blur_hash"
+	ML_BATCH               = "/batch" //* several recommendation requests in one round trip
+)
+
+const (
+	ML_ENGINE    = "ml"    //* the ML API, with the in-process recommender as a fallback when it fails
+	LOCAL_ENGINE = "local" //* only the in-process recommender
+
+	RECOMMENDATION_CANDIDATES = 500 //* latest items scored per request of the in-process recommender
+	RECOMMENDATION_CACHE_SIZE = 100 //* top recommendations cached per user or item
 )
+
+// RecommendationEngine is the engine set with RECOMMENDATION_ENGINE in the env, the ML API when it is not set.
+func RecommendationEngine() string {
+	if initializers.CONFIG.RECOMMENDATION_ENGINE == LOCAL_ENGINE {
+		return LOCAL_ENGINE
+	}
+	return ML_ENGINE
+}
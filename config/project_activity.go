@@ -0,0 +1,3 @@
+package config
+
+const MAX_ACTIVITY_EXPORT_ROWS = 10000
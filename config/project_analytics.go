@@ -0,0 +1,7 @@
+package config
+
+const (
+	ANALYTICS_ROLLUP_WINDOW_DAYS  = 2   //* days before today which are rolled up again, to pick up late changes
+	MAX_ANALYTICS_RANGE_DAYS      = 365 //* for custom ranges
+	PROJECT_VIEWER_RETENTION_DAYS = 2 * MAX_ANALYTICS_RANGE_DAYS
+)
@@ -0,0 +1,10 @@
+package config
+
+import "time"
+
+const (
+	MAX_PROJECT_FILE_SIZE     = 8 * 1024 * 1024   //* below the body limit, to leave room for the rest of the form
+	PROJECT_STORAGE_QUOTA     = 200 * 1024 * 1024 //* total size of all the versions of all the files of a project
+	MAX_PROJECT_FILE_VERSIONS = 10                //* older versions are deleted beyond this
+	PROJECT_FILE_URL_TTL      = 15 * time.Minute
+)
@@ -0,0 +1,8 @@
+package config
+
+import "time"
+
+const (
+	INVITE_LINK_DEFAULT_TTL = 7 * 24 * time.Hour
+	INVITE_LINK_CODE_LENGTH = 18 //* random bytes, url safe base64 encoded in the link
+)
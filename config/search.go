@@ -0,0 +1,22 @@
+package config
+
+import "time"
+
+const (
+	MAX_SEARCH_TERM_LENGTH    = 100
+	AUTOCOMPLETE_HISTORY_DAYS = 90   //* search queries older than this are not used for autocomplete
+	AUTOCOMPLETE_SEED_QUERIES = 5000 //* most searched queries added to autocomplete
+	AUTOCOMPLETE_LIMIT        = 10
+	SEARCH_GROUP_LIMIT        = 5 //* results per type of the federated search
+	SEARCH_FACET_LIMIT        = 10
+
+	TRENDING_SEARCH_HALF_LIFE_HOURS = 24.0 //* a search counts half as much for trending after this long
+	TRENDING_SEARCH_LIMIT           = 15
+	TRENDING_SEARCH_SCAN            = 500              //* trending searches read when filtering them by a search
+	TRENDING_SEARCH_SEED            = 1000             //* searches loaded into trending when it is empty
+	SEARCH_DEDUPE_WINDOW            = 30 * time.Minute //* repeated searches of a user within this count once
+	SEARCH_ROLLUP_RETENTION_DAYS    = 365
+
+	RECENT_SEARCHES_LIMIT = 10
+	RECENT_SEARCHES_TTL   = 90 * 24 * time.Hour
+)
@@ -5,6 +5,7 @@ import (
 	"github.com/Pratham-Mishra04/interact/helpers"
 	"github.com/Pratham-Mishra04/interact/initializers"
 	"github.com/Pratham-Mishra04/interact/models"
+	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
@@ -57,7 +58,7 @@ func GetPopulatedBookMarks(bookmarkType string) func(c *fiber.Ctx) error {
 		case "post":
 			var postBookmarks []models.PostBookmark
 			if err := initializers.DB.
-				Preload("PostItems.Post").
+				Preload("PostItems.Post", API.VisiblePosts(loggedInUserID)).
 				Preload("PostItems.Post.User").
 				Preload("PostItems.Post.RePost").
 				Preload("PostItems.Post.RePost.User").
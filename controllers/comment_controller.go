@@ -6,12 +6,20 @@ import (
 	"github.com/Pratham-Mishra04/interact/initializers"
 	"github.com/Pratham-Mishra04/interact/models"
 	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/utils"
 	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
+func commentsOrder(c *fiber.Ctx) string {
+	if c.Query("sort", "") == "top" {
+		return "no_likes DESC, created_at DESC"
+	}
+	return "created_at DESC"
+}
+
 func GetPostComments(c *fiber.Ctx) error {
 	postID := c.Params("postID")
 
@@ -20,10 +28,14 @@ func GetPostComments(c *fiber.Ctx) error {
 		return &fiber.Error{Code: 400, Message: "Invalid ID"}
 	}
 
+	if err := utils.CheckPostIDVisibility(postID, c.GetRespHeader("loggedInUserID")); err != nil {
+		return err
+	}
+
 	paginatedDB := API.Paginator(c)(initializers.DB)
 
 	var comments []models.Comment
-	if err := paginatedDB.Preload("User").Where("post_id=?", parsedPostID).Order("created_at DESC").Find(&comments).Error; err != nil {
+	if err := paginatedDB.Preload("User").Preload("Mentions").Where("post_id=? AND parent_id IS NULL", parsedPostID).Order(commentsOrder(c)).Find(&comments).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
@@ -45,7 +57,7 @@ func GetProjectComments(c *fiber.Ctx) error {
 	paginatedDB := API.Paginator(c)(initializers.DB)
 
 	var comments []models.Comment
-	if err := paginatedDB.Preload("User").Where("project_id=?", parsedProjectID).Order("created_at DESC").Find(&comments).Error; err != nil {
+	if err := paginatedDB.Preload("User").Preload("Mentions").Where("project_id=? AND parent_id IS NULL", parsedProjectID).Order(commentsOrder(c)).Find(&comments).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
@@ -67,7 +79,7 @@ func GetEventComments(c *fiber.Ctx) error {
 	paginatedDB := API.Paginator(c)(initializers.DB)
 
 	var comments []models.Comment
-	if err := paginatedDB.Preload("User").Where("event_id=?", parsedEventID).Order("created_at DESC").Find(&comments).Error; err != nil {
+	if err := paginatedDB.Preload("User").Preload("Mentions").Where("event_id=? AND parent_id IS NULL", parsedEventID).Order(commentsOrder(c)).Find(&comments).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
@@ -78,6 +90,47 @@ func GetEventComments(c *fiber.Ctx) error {
 	})
 }
 
+func GetCommentReplies(c *fiber.Ctx) error {
+	parsedCommentID, err := uuid.Parse(c.Params("commentID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var comment models.Comment
+	if err := initializers.DB.First(&comment, "id = ?", parsedCommentID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Comment of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if comment.PostID != nil {
+		if err := utils.CheckPostIDVisibility(comment.PostID.String(), c.GetRespHeader("loggedInUserID")); err != nil {
+			return err
+		}
+	}
+
+	cursoredDB := API.CursorPaginator(c, "comments", true)(initializers.DB)
+
+	var replies []models.Comment
+	if err := cursoredDB.Preload("User").Preload("Mentions").Where("parent_id = ?", parsedCommentID).Find(&replies).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	nextCursor := ""
+	if len(replies) > 0 {
+		lastReply := replies[len(replies)-1]
+		nextCursor = API.NextCursor(len(replies), API.CursorLimit(c), lastReply.CreatedAt, lastReply.ID)
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":     "success",
+		"message":    "",
+		"replies":    replies,
+		"nextCursor": nextCursor,
+	})
+}
+
 func AddComment(c *fiber.Ctx) error {
 	loggedInUserID := c.GetRespHeader("loggedInUserID")
 	parsedUserID, _ := uuid.Parse(loggedInUserID)
@@ -87,6 +140,7 @@ func AddComment(c *fiber.Ctx) error {
 		PostID    string `json:"postID"`
 		ProjectID string `json:"projectID"`
 		EventID   string `json:"eventID"`
+		ParentID  string `json:"parentID"`
 	}
 	if err := c.BodyParser(&reqBody); err != nil {
 		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
@@ -96,9 +150,49 @@ func AddComment(c *fiber.Ctx) error {
 	projectID := reqBody.ProjectID
 	eventID := reqBody.EventID
 
+	//* replies are always added to the thread of their parent
+	var parent models.Comment
+	if reqBody.ParentID != "" {
+		parsedParentID, err := uuid.Parse(reqBody.ParentID)
+		if err != nil {
+			return &fiber.Error{Code: 400, Message: "Invalid ID."}
+		}
+
+		if err := initializers.DB.First(&parent, "id = ?", parsedParentID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return &fiber.Error{Code: 400, Message: "No Comment of this ID found."}
+			}
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		postID, projectID, eventID = "", "", ""
+		if parent.PostID != nil {
+			postID = parent.PostID.String()
+		} else if parent.ProjectID != nil {
+			projectID = parent.ProjectID.String()
+		} else if parent.EventID != nil {
+			eventID = parent.EventID.String()
+		}
+	}
+
+	mentions, err := utils.GetMentions(reqBody.Content)
+	if err != nil {
+		return err
+	}
+
 	comment := models.Comment{
-		UserID:  parsedUserID,
-		Content: reqBody.Content,
+		UserID:   parsedUserID,
+		Content:  reqBody.Content,
+		Mentions: mentions,
+	}
+
+	if parent.ID != uuid.Nil {
+		comment.ParentID = &parent.ID
+		comment.Depth = parent.Depth + 1
+		if parent.Depth >= config.MAX_COMMENT_DEPTH && parent.ParentID != nil {
+			comment.ParentID = parent.ParentID
+			comment.Depth = parent.Depth
+		}
 	}
 
 	if postID != "" {
@@ -106,22 +200,25 @@ func AddComment(c *fiber.Ctx) error {
 		if err != nil {
 			return &fiber.Error{Code: 400, Message: "Invalid ID."}
 		}
+		if err := utils.CheckPostIDVisibility(postID, loggedInUserID); err != nil {
+			return err
+		}
 		comment.PostID = &parsedPostID
-		go routines.IncrementPostCommentsAndSendNotification(parsedPostID, parsedUserID)
+		go routines.IncrementPostCommentsAndSendNotification(parsedPostID, parsedUserID, parent.UserID)
 	} else if projectID != "" {
 		parsedProjectID, err := uuid.Parse(projectID)
 		if err != nil {
 			return &fiber.Error{Code: 400, Message: "Invalid ID."}
 		}
 		comment.ProjectID = &parsedProjectID
-		go routines.IncrementProjectCommentsAndSendNotification(parsedProjectID, parsedUserID)
+		go routines.IncrementProjectCommentsAndSendNotification(parsedProjectID, parsedUserID, parent.UserID)
 	} else if eventID != "" {
 		parsedEventID, err := uuid.Parse(eventID)
 		if err != nil {
 			return &fiber.Error{Code: 400, Message: "Invalid ID."}
 		}
 		comment.EventID = &parsedEventID
-		go routines.IncrementEventCommentsAndSendNotification(parsedEventID, parsedUserID)
+		go routines.IncrementEventCommentsAndSendNotification(parsedEventID, parsedUserID, parent.UserID)
 	}
 
 	result := initializers.DB.Create(&comment)
@@ -129,10 +226,15 @@ func AddComment(c *fiber.Ctx) error {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: result.Error.Error(), Err: result.Error}
 	}
 
-	if err := initializers.DB.Preload("User").First(&comment).Error; err != nil {
+	if err := initializers.DB.Preload("User").Preload("Mentions").First(&comment).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
+	go routines.SendMentionNotifications(parsedUserID, comment.Mentions, 18, comment.PostID, comment.ProjectID, comment.EventID)
+	if comment.ParentID != nil {
+		go routines.IncrementCommentRepliesAndSendNotification(*comment.ParentID, parent.ID, comment)
+	}
+
 	return c.Status(201).JSON(fiber.Map{
 		"status":  "success",
 		"message": "Comment Added",
@@ -150,7 +252,7 @@ func UpdateComment(c *fiber.Ctx) error {
 	}
 
 	var comment models.Comment
-	if err := initializers.DB.First(&comment, "id = ? AND user_id=?", parsedCommentID, loggedInUserID).Error; err != nil {
+	if err := initializers.DB.Preload("Mentions").First(&comment, "id = ? AND user_id=?", parsedCommentID, loggedInUserID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return &fiber.Error{Code: 400, Message: "No Comment of this ID found."}
 		}
@@ -164,14 +266,42 @@ func UpdateComment(c *fiber.Ctx) error {
 		return &fiber.Error{Code: 400, Message: "Invalid Request Body."}
 	}
 
+	contentChanged := reqBody.Content != "" && reqBody.Content != comment.Content
+	oldContent := comment.Content
+
 	if reqBody.Content != "" {
 		comment.Content = reqBody.Content
 	}
 
 	comment.Edited = true
 
-	if err := initializers.DB.Save(&comment).Error; err != nil {
-		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if contentChanged {
+			if err := saveRevision(tx, models.Revision{CommentID: &comment.ID}, oldContent, comment.CreatedAt); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Omit("Mentions").Save(&comment).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if contentChanged {
+		mentions, err := utils.GetMentions(comment.Content)
+		if err != nil {
+			return err
+		}
+
+		if err := replaceMentions(models.Mention{CommentID: &comment.ID}, mentions); err != nil {
+			return err
+		}
+
+		go routines.SendMentionNotifications(comment.UserID, newMentions(comment.Mentions, mentions), 18, comment.PostID, comment.ProjectID, comment.EventID)
+		comment.Mentions = mentions
 	}
 
 	return c.Status(200).JSON(fiber.Map{
@@ -225,16 +355,29 @@ func DeleteComment(c *fiber.Ctx) error {
 	projectID := comment.ProjectID
 	eventID := comment.EventID
 
+	//* the replies are deleted along with the comment, so the whole thread is taken off the counters
+	var threadSize int
+	if err := initializers.DB.Raw(`WITH RECURSIVE thread AS (
+			SELECT id FROM comments WHERE id = ?
+			UNION ALL
+			SELECT comments.id FROM comments JOIN thread ON comments.parent_id = thread.id
+		) SELECT COUNT(*) FROM thread`, comment.ID).Scan(&threadSize).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
 	if err := initializers.DB.Delete(&comment).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
 	if postID != nil {
-		go routines.DecrementPostComments(*postID)
+		go routines.DecrementPostComments(*postID, threadSize)
 	} else if projectID != nil {
-		go routines.DecrementProjectComments(*projectID)
+		go routines.DecrementProjectComments(*projectID, threadSize)
 	} else if eventID != nil {
-		go routines.DecrementEventComments(*eventID)
+		go routines.DecrementEventComments(*eventID, threadSize)
+	}
+	if comment.ParentID != nil {
+		go routines.DecrementCommentReplies(*comment.ParentID)
 	}
 
 	return c.Status(204).JSON(fiber.Map{
@@ -126,7 +126,7 @@ func GetFollowers(c *fiber.Ctx) error {
 	searchDB := API.Search(c, 0)(paginatedDB)
 
 	var followers []models.FollowFollower
-	if err := searchDB.Preload("Follower").Where("followed_id = ?", userID).Find(&followers).Error; err != nil {
+	if err := searchDB.Preload("Follower").Joins("JOIN users ON users.id = follow_followers.follower_id").Where("followed_id = ?", userID).Find(&followers).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
@@ -153,7 +153,7 @@ func GetFollowing(c *fiber.Ctx) error {
 	searchDB := API.Search(c, 0)(paginatedDB)
 
 	var following []models.FollowFollower
-	if err := searchDB.Preload("Followed").Where("follower_id = ?", userID).Find(&following).Error; err != nil {
+	if err := searchDB.Preload("Followed").Joins("JOIN users ON users.id = follow_followers.followed_id").Where("follower_id = ?", userID).Find(&following).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
@@ -6,11 +6,14 @@ import (
 	"github.com/Pratham-Mishra04/interact/initializers"
 	"github.com/Pratham-Mishra04/interact/models"
 	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/utils"
 	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
 	"github.com/gofiber/fiber/v2"
 )
 
 func GetLatestPosts(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
 	paginatedDB := API.Paginator(c)(initializers.DB)
 	var posts []models.Post
 
@@ -22,13 +25,23 @@ func GetLatestPosts(c *fiber.Ctx) error {
 		Preload("RePost.User").
 		Preload("RePost.TaggedUsers").
 		Preload("TaggedUsers").
+		Preload("Mentions").
+		Preload("LinkPreviews").
+		Preload("Hashtags").
+		Preload("Poll.Options").
 		Joins("JOIN users ON posts.user_id = users.id AND users.active = ?", true).
+		Where("posts.status = ?", models.PostPublished).
+		Scopes(API.VisiblePosts(loggedInUserID)).
 		Select("*, posts.id, posts.created_at").
 		Order("posts.created_at DESC").
 		Find(&posts).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
+	if err := utils.PreparePolls(posts, loggedInUserID); err != nil {
+		return err
+	}
+
 	go routines.IncrementPostImpression(posts)
 
 	return c.Status(200).JSON(fiber.Map{
@@ -47,7 +60,7 @@ func GetLatestProjects(c *fiber.Ctx) error {
 		Preload("User").
 		Preload("Memberships").
 		Order("created_at DESC").
-		Where("is_private = ?", false).
+		Where("is_private = ? AND is_archived = ?", false, false).
 		Find(&projects).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
@@ -9,6 +9,7 @@ import (
 	"github.com/Pratham-Mishra04/interact/initializers"
 	"github.com/Pratham-Mishra04/interact/models"
 	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/utils"
 	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
 	"github.com/gofiber/fiber/v2"
 	"gorm.io/gorm"
@@ -78,10 +79,8 @@ func AddSearchQuery(c *fiber.Ctx) error {
 	if err := c.BodyParser(&reqBody); err != nil {
 		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
 	}
-	searchQuery := models.SearchQuery{
-		Query: strings.ToLower(strings.TrimSpace(reqBody.Search)),
-	}
-	initializers.DB.Create(&searchQuery)
+	go routines.LogSearchQuery(reqBody.Search, c.GetRespHeader("loggedInUserID"), c.IP())
+
 	return c.Status(201).JSON(fiber.Map{
 		"status": "success",
 	})
@@ -151,7 +150,7 @@ func GetMostLikedProjects(c *fiber.Ctx) error {
 		Preload("User").
 		Preload("Memberships").
 		Order("no_likes DESC").
-		Where("is_private = ?", false).
+		Where("is_private = ? AND is_archived = ?", false, false).
 		Find(&projects).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
@@ -220,3 +219,59 @@ func GetOrganizationalUser(c *fiber.Ctx) error {
 		"organization": organization,
 	})
 }
+
+func GetHashtagPosts(c *fiber.Ctx) error {
+	tag := utils.NormalizeHashtag(c.Params("tag"))
+	if tag == "" {
+		return &fiber.Error{Code: 400, Message: "Invalid Hashtag."}
+	}
+
+	var hashtag models.Hashtag
+	if err := initializers.DB.First(&hashtag, "name = ?", tag).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Hashtag of this name found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	paginatedDB := API.Paginator(c)(initializers.DB)
+
+	db := paginatedDB.
+		Preload("User").
+		Preload("RePost").
+		Preload("RePost.User").
+		Preload("RePost.TaggedUsers").
+		Preload("TaggedUsers").
+		Preload("Mentions").
+		Preload("LinkPreviews").
+		Preload("Hashtags").
+		Preload("Poll.Options").
+		Joins("JOIN post_hashtags ON post_hashtags.post_id = posts.id AND post_hashtags.hashtag_id = ?", hashtag.ID).
+		Where("posts.status = ?", models.PostPublished).
+		Scopes(API.VisiblePosts(c.GetRespHeader("loggedInUserID")))
+
+	var posts []models.Post
+	if c.Query("order", "") == string(API.Trending) {
+		db = API.Order(db, API.Trending, API.Posts)
+	} else {
+		db = db.Joins("JOIN users ON posts.user_id = users.id AND users.active = ?", true).
+			Select("*, posts.id, posts.created_at").
+			Order("posts.created_at DESC")
+	}
+
+	if err := db.Find(&posts).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := utils.PreparePolls(posts, c.GetRespHeader("loggedInUserID")); err != nil {
+		return err
+	}
+
+	go routines.IncrementPostImpression(posts)
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"hashtag": hashtag,
+		"posts":   posts,
+	})
+}
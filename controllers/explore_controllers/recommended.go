@@ -1,6 +1,8 @@
 package explore_controllers
 
 import (
+	"strconv"
+
 	"github.com/Pratham-Mishra04/interact/config"
 	"github.com/Pratham-Mishra04/interact/helpers"
 	"github.com/Pratham-Mishra04/interact/initializers"
@@ -12,29 +14,113 @@ import (
 	"github.com/google/uuid"
 )
 
+func getRecommendedPosts(recommendations []string, loggedInUserID string) ([]models.Post, error) {
+	var posts []models.Post
+	if err := initializers.DB.
+		Preload("User").
+		Preload("Poll.Options").
+		Scopes(API.VisiblePosts(loggedInUserID)).
+		Where("id IN ? AND status = ?", recommendations, models.PostPublished).
+		Find(&posts).Error; err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := utils.PreparePolls(posts, loggedInUserID); err != nil {
+		return nil, err
+	}
+
+	go routines.IncrementPostImpression(posts)
+
+	return posts, nil
+}
+
+func getRecommendedProjects(recommendations []string) ([]models.Project, error) {
+	var projects []models.Project
+	if err := initializers.DB.
+		Preload("User").
+		Preload("Memberships").
+		Where("id IN ? AND is_archived = ?", recommendations, false).
+		Find(&projects).Error; err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go routines.IncrementProjectImpression(projects)
+
+	return projects, nil
+}
+
+func getRecommendedOpenings(recommendations []string, loggedInUserID uuid.UUID) ([]models.Opening, error) {
+	var openings []models.Opening
+	if err := initializers.DB.
+		Preload("User").
+		Preload("Project").
+		Where("id IN ?", recommendations).
+		Find(&openings).Error; err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	var filteredOpenings []models.Opening
+	for _, opening := range openings {
+		if opening.Project.UserID != loggedInUserID && !opening.Project.IsPrivate && !opening.Project.IsArchived {
+			filteredOpenings = append(filteredOpenings, opening)
+		}
+	}
+
+	go routines.IncrementOpeningImpression(filteredOpenings)
+
+	return filteredOpenings, nil
+}
+
+// GetRecommendations gets the recommended posts, projects and openings of the explore page in one round trip to the ML API.
+func GetRecommendations(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+	parsedLoggedInUserID, _ := uuid.Parse(loggedInUserID)
+
+	limit, _ := strconv.Atoi(c.Query("limit", "10"))
+	page, _ := strconv.Atoi(c.Query("page", "1"))
+
+	recommendations := utils.GetRecommendationsBatch(loggedInUserID, []string{config.POST_RECOMMENDATION, config.PROJECT_RECOMMENDATION, config.OPENING_RECOMMENDATION}, limit, page)
+
+	posts, err := getRecommendedPosts(recommendations[config.POST_RECOMMENDATION], loggedInUserID)
+	if err != nil {
+		return err
+	}
+
+	projects, err := getRecommendedProjects(recommendations[config.PROJECT_RECOMMENDATION])
+	if err != nil {
+		return err
+	}
+
+	openings, err := getRecommendedOpenings(recommendations[config.OPENING_RECOMMENDATION], parsedLoggedInUserID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":   "success",
+		"posts":    posts,
+		"projects": projects,
+		"openings": openings,
+	})
+}
+
 func GetRecommendedPosts(c *fiber.Ctx) error {
 	loggedInUserID := c.GetRespHeader("loggedInUserID")
 
-	recommendations, err := utils.MLReq(loggedInUserID, config.POST_RECOMMENDATION)
+	recommendations, err := utils.GetRecommendations(loggedInUserID, config.POST_RECOMMENDATION)
 	if err != nil {
-		helpers.LogServerError("Error Fetching from ML API", err, c.Path())
+		helpers.LogServerError("Error Fetching Recommendations", err, c.Path())
 		return c.Status(200).JSON(fiber.Map{
 			"status": "success",
 			"posts":  nil,
 		})
 	}
 
-	var posts []models.Post
-
-	if err := initializers.DB.
-		Preload("User").
-		Where("id IN ?", recommendations).
-		Find(&posts).Error; err != nil {
-		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	posts, err := getRecommendedPosts(recommendations, loggedInUserID)
+	if err != nil {
+		return err
 	}
 
-	go routines.IncrementPostImpression(posts)
-
 	return c.Status(200).JSON(fiber.Map{
 		"status": "success",
 		"posts":  posts,
@@ -45,33 +131,20 @@ func GetRecommendedOpenings(c *fiber.Ctx) error {
 	loggedInUserID := c.GetRespHeader("loggedInUserID")
 	parsedLoggedInUserID, _ := uuid.Parse(loggedInUserID)
 
-	recommendations, err := utils.MLReq(loggedInUserID, config.OPENING_RECOMMENDATION)
+	recommendations, err := utils.GetRecommendations(loggedInUserID, config.OPENING_RECOMMENDATION)
 	if err != nil {
-		helpers.LogServerError("Error Fetching from ML API", err, c.Path())
+		helpers.LogServerError("Error Fetching Recommendations", err, c.Path())
 		return c.Status(200).JSON(fiber.Map{
 			"status":   "success",
 			"openings": nil,
 		})
 	}
 
-	var openings []models.Opening
-
-	if err := initializers.DB.
-		Preload("User").
-		Where("id IN ?", recommendations).
-		Find(&openings).Error; err != nil {
-		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
-	}
-
-	var filteredOpenings []models.Opening
-	for _, opening := range openings {
-		if opening.Project.UserID != parsedLoggedInUserID && !opening.Project.IsPrivate {
-			filteredOpenings = append(filteredOpenings, opening)
-		}
+	filteredOpenings, err := getRecommendedOpenings(recommendations, parsedLoggedInUserID)
+	if err != nil {
+		return err
 	}
 
-	go routines.IncrementOpeningImpression(filteredOpenings)
-
 	return c.Status(200).JSON(fiber.Map{
 		"status":   "success",
 		"openings": filteredOpenings,
@@ -81,27 +154,20 @@ func GetRecommendedOpenings(c *fiber.Ctx) error {
 func GetRecommendedProjects(c *fiber.Ctx) error {
 	loggedInUserID := c.GetRespHeader("loggedInUserID")
 
-	recommendations, err := utils.MLReq(loggedInUserID, config.PROJECT_RECOMMENDATION)
+	recommendations, err := utils.GetRecommendations(loggedInUserID, config.PROJECT_RECOMMENDATION)
 	if err != nil {
-		helpers.LogServerError("Error Fetching from ML API", err, c.Path())
+		helpers.LogServerError("Error Fetching Recommendations", err, c.Path())
 		return c.Status(200).JSON(fiber.Map{
 			"status":   "success",
 			"projects": nil,
 		})
 	}
 
-	var projects []models.Project
-
-	if err := initializers.DB.
-		Preload("User").
-		Preload("Memberships").
-		Where("id IN ?", recommendations).
-		Find(&projects).Error; err != nil {
-		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	projects, err := getRecommendedProjects(recommendations)
+	if err != nil {
+		return err
 	}
 
-	go routines.IncrementProjectImpression(projects)
-
 	return c.Status(200).JSON(fiber.Map{
 		"status":   "success",
 		"projects": projects,
@@ -141,9 +207,9 @@ func GetRecommendedUsers(c *fiber.Ctx) error {
 func GetRecommendedEvents(c *fiber.Ctx) error {
 	loggedInUserID := c.GetRespHeader("loggedInUserID")
 
-	recommendations, err := utils.MLReq(loggedInUserID, config.EVENT_RECOMMENDATION)
+	recommendations, err := utils.GetRecommendations(loggedInUserID, config.EVENT_RECOMMENDATION)
 	if err != nil {
-		helpers.LogServerError("Error Fetching from ML API", err, c.Path())
+		helpers.LogServerError("Error Fetching Recommendations", err, c.Path())
 		return c.Status(200).JSON(fiber.Map{
 			"status": "success",
 			"events": nil,
@@ -172,7 +238,7 @@ func GetRecommendedOrganizationalUsers(c *fiber.Ctx) error {
 	loggedInUserID := c.GetRespHeader("loggedInUserID")
 	paginatedDB := API.Paginator(c)(initializers.DB)
 
-	searchedDB := API.Search(c, 0)(paginatedDB)
+	searchedDB := API.Search(c, 7)(paginatedDB)
 
 	type UserWithOrganization struct {
 		models.User
@@ -0,0 +1,288 @@
+package explore_controllers
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/utils"
+	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+type SearchFacet struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type SearchResult struct {
+	Items  interface{}              `json:"items"`
+	Count  int64                    `json:"count"`
+	Facets map[string][]SearchFacet `json:"facets,omitempty"`
+}
+
+type searchFacetSpec struct {
+	name       string
+	expression string
+	join       string //* to reach the column of the expression from the searched table
+}
+
+type searchGroup struct {
+	table  string
+	query  func(c *fiber.Ctx, viewerID string) *gorm.DB //* the matching rows, with the search and filters applied
+	find   func(db *gorm.DB, viewerID string) (interface{}, error)
+	facets []searchFacetSpec
+}
+
+var searchGroupOrder = []string{"users", "orgs", "projects", "openings", "events", "posts"}
+
+var searchGroups = map[string]searchGroup{
+	"users": {
+		table: "users",
+		query: func(c *fiber.Ctx, _ string) *gorm.DB {
+			return API.Filter(c, 2)(API.Search(c, 0)(initializers.DB.Model(&models.User{}))).
+				Where("users.active = ? AND users.onboarding_completed = ? AND users.verified = ? AND users.organization_status = ?", true, true, true, false)
+		},
+		find: func(db *gorm.DB, _ string) (interface{}, error) {
+			var users []models.User
+			err := db.Preload("Profile").Find(&users).Error
+			return users, err
+		},
+		facets: []searchFacetSpec{
+			{"tags", "unnest(users.tags)", ""},
+			{"location", "profiles.location", "JOIN profiles ON profiles.user_id = users.id"},
+			{"school", "profiles.school", "JOIN profiles ON profiles.user_id = users.id"},
+		},
+	},
+	"orgs": {
+		table: "organizations",
+		query: func(c *fiber.Ctx, _ string) *gorm.DB {
+			return API.Search(c, 7)(initializers.DB.Model(&models.Organization{})).
+				Joins("JOIN users ON users.id = organizations.user_id").
+				Where("users.active = ? AND users.verified = ? AND users.organization_status = ?", true, true, true)
+		},
+		find: func(db *gorm.DB, _ string) (interface{}, error) {
+			var organizations []models.Organization
+			err := db.Preload("User").Find(&organizations).Error
+			return organizations, err
+		},
+	},
+	"projects": {
+		table: "projects",
+		query: func(c *fiber.Ctx, _ string) *gorm.DB {
+			return API.Filter(c, 1)(API.Search(c, 1)(initializers.DB.Model(&models.Project{}))).
+				Where("projects.is_private = ? AND projects.is_archived = ?", false, false)
+		},
+		find: func(db *gorm.DB, _ string) (interface{}, error) {
+			var projects []models.Project
+			err := db.Preload("User").Find(&projects).Error
+			return projects, err
+		},
+		facets: []searchFacetSpec{
+			{"tags", "unnest(projects.tags)", ""},
+			{"category", "projects.category", ""},
+		},
+	},
+	"openings": {
+		table: "openings",
+		query: func(c *fiber.Ctx, _ string) *gorm.DB {
+			return API.Filter(c, 4)(API.Search(c, 3)(initializers.DB.Model(&models.Opening{}))).
+				Where("openings.active = ? AND projects.is_private = ? AND projects.is_archived = ?", true, false, false)
+		},
+		find: func(db *gorm.DB, _ string) (interface{}, error) {
+			var openings []models.Opening
+			err := db.Preload("Project").Preload("User").Find(&openings).Error
+			return openings, err
+		},
+		facets: []searchFacetSpec{
+			{"tags", "unnest(openings.tags)", ""},
+			{"category", "projects.category", "JOIN projects ON projects.id = openings.project_id"},
+		},
+	},
+	"events": {
+		table: "events",
+		query: func(c *fiber.Ctx, _ string) *gorm.DB {
+			return API.Filter(c, 3)(API.Search(c, 4)(initializers.DB.Model(&models.Event{})))
+		},
+		find: func(db *gorm.DB, _ string) (interface{}, error) {
+			var events []models.Event
+			err := db.Preload("Organization").Preload("Organization.User").Find(&events).Error
+			return events, err
+		},
+		facets: []searchFacetSpec{
+			{"tags", "unnest(events.tags)", ""},
+			{"category", "events.category", ""},
+			{"location", "events.location", ""},
+		},
+	},
+	"posts": {
+		table: "posts",
+		query: func(c *fiber.Ctx, viewerID string) *gorm.DB {
+			return API.Search(c, 2)(initializers.DB.Model(&models.Post{})).
+				Joins("JOIN users ON posts.user_id = users.id AND users.active = ?", true).
+				Scopes(API.VisiblePosts(viewerID)).
+				Where("posts.status = ?", models.PostPublished)
+		},
+		find: func(db *gorm.DB, viewerID string) (interface{}, error) {
+			var posts []models.Post
+			if err := db.
+				Preload("User").
+				Preload("TaggedUsers").
+				Preload("Mentions").
+				Preload("LinkPreviews").
+				Preload("Hashtags").
+				Preload("Poll.Options").
+				Find(&posts).Error; err != nil {
+				return nil, err
+			}
+			if err := utils.PreparePolls(posts, viewerID); err != nil {
+				return nil, err
+			}
+			return posts, nil
+		},
+		facets: []searchFacetSpec{
+			{"tags", "unnest(posts.tags)", ""},
+		},
+	},
+}
+
+func getSearchFacet(group searchGroup, facet searchFacetSpec, c *fiber.Ctx, viewerID string) ([]SearchFacet, error) {
+	matches := group.query(c, viewerID).Select(group.table + ".id")
+
+	facets := []SearchFacet{}
+	if err := initializers.DB.Raw(
+		"SELECT value, COUNT(*) AS count FROM (SELECT "+facet.expression+" AS value FROM (?) AS matches JOIN "+group.table+" ON "+group.table+".id = matches.id "+facet.join+") AS facet "+
+			"WHERE value IS NOT NULL AND value <> '' GROUP BY value ORDER BY count DESC, value LIMIT ?",
+		matches, config.SEARCH_FACET_LIMIT,
+	).Scan(&facets).Error; err != nil {
+		return nil, err
+	}
+
+	return facets, nil
+}
+
+// Search searches users, orgs, projects, openings, events and posts at once,
+// giving the top results of every type with the number of matches and their facets.
+func Search(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	searchStr := utils.NormalizeSearchTerm(c.Query("search", ""))
+	if searchStr == "" {
+		return &fiber.Error{Code: 400, Message: "Invalid Search."}
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", strconv.Itoa(config.SEARCH_GROUP_LIMIT)))
+	if err != nil || limit <= 0 || limit > 20 {
+		limit = config.SEARCH_GROUP_LIMIT
+	}
+
+	types := searchGroupOrder
+	if typesStr := c.Query("types", ""); typesStr != "" {
+		types = nil
+		for _, groupType := range strings.Split(typesStr, ",") {
+			if _, ok := searchGroups[strings.TrimSpace(groupType)]; ok {
+				types = append(types, strings.TrimSpace(groupType))
+			}
+		}
+	}
+
+	results := make(map[string]SearchResult, len(types))
+	for _, groupType := range types {
+		group := searchGroups[groupType]
+
+		var count int64
+		if err := group.query(c, loggedInUserID).Count(&count).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		items, err := group.find(group.query(c, loggedInUserID).Limit(limit), loggedInUserID)
+		if err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		facets := make(map[string][]SearchFacet, len(group.facets))
+		for _, facet := range group.facets {
+			values, err := getSearchFacet(group, facet, c, loggedInUserID)
+			if err != nil {
+				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+			}
+			facets[facet.name] = values
+		}
+
+		results[groupType] = SearchResult{
+			Items:  items,
+			Count:  count,
+			Facets: facets,
+		}
+	}
+
+	go routines.LogSearchQuery(searchStr, loggedInUserID, c.IP())
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "",
+		"search":  searchStr,
+		"results": results,
+	})
+}
+
+// GetSearchAutocomplete suggests the most popular searches and titles starting with the search.
+func GetSearchAutocomplete(c *fiber.Ctx) error {
+	prefix := utils.NormalizeSearchTerm(c.Query("search", ""))
+	if prefix == "" {
+		return c.Status(200).JSON(fiber.Map{
+			"status":      "success",
+			"suggestions": []string{},
+		})
+	}
+
+	suggestions, err := cache.GetAutocompleteSuggestions(prefix, config.AUTOCOMPLETE_LIMIT)
+	if err != nil {
+		suggestions = []string{}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":      "success",
+		"suggestions": suggestions,
+	})
+}
+
+func GetRecentSearches(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	searches, err := cache.GetRecentSearches(loggedInUserID)
+	if err != nil {
+		return helpers.AppError{Code: 500, Message: config.SERVER_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":   "success",
+		"searches": searches,
+	})
+}
+
+// DeleteRecentSearches removes the search from the recent searches of the user, or all of them if no search is given.
+func DeleteRecentSearches(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	var err error
+	if searchStr := utils.NormalizeSearchTerm(c.Query("search", "")); searchStr != "" {
+		err = cache.RemoveRecentSearch(loggedInUserID, searchStr)
+	} else {
+		err = cache.ClearRecentSearches(loggedInUserID)
+	}
+	if err != nil {
+		return helpers.AppError{Code: 500, Message: config.SERVER_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(204).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Recent searches cleared.",
+	})
+}
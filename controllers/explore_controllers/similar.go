@@ -59,9 +59,9 @@ func GetSimilarProjects(c *fiber.Ctx) error {
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 
-	recommendations, err := utils.MLReq(project.ID.String(), config.PROJECT_SIMILAR, limit, page)
+	recommendations, err := utils.GetRecommendations(project.ID.String(), config.PROJECT_SIMILAR, limit, page)
 	if err != nil {
-		helpers.LogServerError("Error Fetching from ML API", err, c.Path())
+		helpers.LogServerError("Error Fetching Recommendations", err, c.Path())
 	}
 
 	var projects []models.Project
@@ -71,7 +71,7 @@ func GetSimilarProjects(c *fiber.Ctx) error {
 			Preload("User").
 			Preload("Memberships").
 			Where("id <> ?", project.ID).
-			Where("is_private=?", false).
+			Where("is_private = ? AND is_archived = ?", false, false).
 			Where("category = ? OR tags && ?", project.Category, pq.StringArray(project.Tags)).
 			Order("total_no_views DESC").
 			Find(&projects).Error; err != nil {
@@ -81,7 +81,7 @@ func GetSimilarProjects(c *fiber.Ctx) error {
 		if err := initializers.DB.
 			Preload("User").
 			Preload("Memberships").
-			Where("id IN ?", recommendations).
+			Where("id IN ? AND is_archived = ?", recommendations, false).
 			Find(&projects).Error; err != nil {
 			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 		}
@@ -108,9 +108,9 @@ func GetSimilarEvents(c *fiber.Ctx) error {
 	limit, _ := strconv.Atoi(c.Query("limit", "10"))
 	page, _ := strconv.Atoi(c.Query("page", "1"))
 
-	recommendations, err := utils.MLReq(event.ID.String(), config.EVENT_SIMILAR, limit, page)
+	recommendations, err := utils.GetRecommendations(event.ID.String(), config.EVENT_SIMILAR, limit, page)
 	if err != nil {
-		helpers.LogServerError("Error Fetching from ML API", err, c.Path())
+		helpers.LogServerError("Error Fetching Recommendations", err, c.Path())
 	}
 
 	var events []models.Event
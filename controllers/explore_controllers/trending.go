@@ -1,36 +1,43 @@
 package explore_controllers
 
 import (
+	"strings"
+
+	"github.com/Pratham-Mishra04/interact/cache"
 	"github.com/Pratham-Mishra04/interact/config"
 	"github.com/Pratham-Mishra04/interact/helpers"
 	"github.com/Pratham-Mishra04/interact/initializers"
 	"github.com/Pratham-Mishra04/interact/models"
 	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/utils"
 	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
 func GetTrendingSearches(c *fiber.Ctx) error {
-	var trendingSearches []string
-	// timeWindow := time.Now().Add(-10 * 24 * time.Hour)
+	searchStr := utils.NormalizeSearchTerm(c.Query("search", ""))
+
+	count := config.TRENDING_SEARCH_LIMIT
+	if searchStr != "" {
+		count = config.TRENDING_SEARCH_SCAN
+	}
 
-	var searchCounts []struct {
-		Query  string
-		Counts int
+	searches, err := cache.GetTrendingSearches(count)
+	if err != nil {
+		return helpers.AppError{Code: 500, Message: config.SERVER_ERROR, LogMessage: err.Error(), Err: err}
 	}
-	searchedDB := API.Search(c, 5)(initializers.DB)
-	searchedDB.Table("search_queries").
-		Select("LOWER(query) as query, COUNT(*) as counts"). // Ensure lowercase comparison
-		// Where("timestamp > ?", timeWindow).
-		Group("LOWER(query)"). // Ensure grouping with lowercase
-		Order("counts DESC").
-		Limit(15).
-		Scan(&searchCounts)
-
-	for _, searchCount := range searchCounts {
-		trendingSearches = append(trendingSearches, searchCount.Query)
+
+	trendingSearches := []string{}
+	for _, search := range searches {
+		if strings.Contains(search, searchStr) {
+			trendingSearches = append(trendingSearches, search)
+			if len(trendingSearches) == config.TRENDING_SEARCH_LIMIT {
+				break
+			}
+		}
 	}
+
 	return c.Status(200).JSON(fiber.Map{
 		"status":   "success",
 		"searches": trendingSearches,
@@ -52,9 +59,11 @@ func GetTrendingPosts(c *fiber.Ctx) error {
 			Preload("RePost.User").
 			Preload("RePost.TaggedUsers").
 			Preload("TaggedUsers").
-			Joins("JOIN users ON posts.user_id = users.id AND users.active = ?", true).
-			Select("*, posts.id, posts.created_at, (2 * no_likes + no_comments + 5 * no_shares) / (1 + EXTRACT(EPOCH FROM age(NOW(), posts.created_at)) / 3600 / 24 / 7) AS weighted_average"). //! 7 days
-			Order("weighted_average DESC, posts.created_at ASC").
+			Preload("Mentions").
+			Preload("LinkPreviews").
+			Preload("Hashtags").
+			Preload("Poll.Options").
+			Scopes(API.TrendingPosts, API.VisiblePosts(loggedInUserID)).
 			Find(&posts).Error; err != nil {
 			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 		}
@@ -64,15 +73,18 @@ func GetTrendingPosts(c *fiber.Ctx) error {
 			Preload("RePost").
 			Preload("RePost.User").
 			Preload("RePost.TaggedUsers").
+			Preload("Poll.Options").
 			Where("user_id <> ?", loggedInUserID).
-			Joins("JOIN users ON posts.user_id = users.id AND users.active = ?", true).
-			Select("*, posts.id, posts.created_at, (2 * no_likes + no_comments + 5 * no_shares) / (1 + EXTRACT(EPOCH FROM age(NOW(), posts.created_at)) / 3600 / 24 / 7) AS weighted_average"). //! 7 days
-			Order("weighted_average DESC, posts.created_at ASC").
+			Scopes(API.TrendingPosts, API.VisiblePosts(loggedInUserID)).
 			Find(&posts).Error; err != nil {
 			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 		}
 	}
 
+	if err := utils.PreparePolls(posts, loggedInUserID); err != nil {
+		return err
+	}
+
 	go routines.IncrementPostImpression(posts)
 
 	return c.Status(200).JSON(fiber.Map{
@@ -116,7 +128,7 @@ func GetTrendingOpenings(c *fiber.Ctx) error {
 	}
 	var filteredOpenings []models.Opening
 	for _, opening := range openings {
-		if opening.Project.UserID != parsedLoggedInUserID && !opening.Project.IsPrivate {
+		if opening.Project.UserID != parsedLoggedInUserID && !opening.Project.IsPrivate && !opening.Project.IsArchived {
 			filteredOpenings = append(filteredOpenings, opening)
 		}
 	}
@@ -145,7 +157,7 @@ func GetTrendingProjects(c *fiber.Ctx) error {
 			Preload("Memberships").
 			Select("*, (projects.total_no_views + 3 * no_likes + 2 * no_comments + 5 * no_shares) AS weighted_average").
 			Order("weighted_average DESC").
-			Where("user_id <> ? AND is_private = ?", loggedInUserID, false).
+			Where("user_id <> ? AND is_private = ? AND is_archived = ?", loggedInUserID, false, false).
 			Find(&projects).Error; err != nil {
 			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 		}
@@ -155,7 +167,7 @@ func GetTrendingProjects(c *fiber.Ctx) error {
 			Preload("Memberships").
 			Select("*, (total_no_views + 3 * no_likes + 2 * no_comments + 5 * no_shares) AS weighted_average").
 			Order("weighted_average DESC").
-			Where("is_private = ?", false).
+			Where("is_private = ? AND is_archived = ?", false, false).
 			Find(&projects).Error; err != nil {
 			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 		}
@@ -225,7 +237,7 @@ func GetTrendingEvents(c *fiber.Ctx) error {
 
 func GetTrendingOrganizationalUsers(c *fiber.Ctx) error {
 	paginatedDB := API.Paginator(c)(initializers.DB)
-	searchedDB := API.Search(c, 0)(paginatedDB)
+	searchedDB := API.Search(c, 7)(paginatedDB)
 
 	type UserWithOrganization struct {
 		models.User
@@ -277,3 +289,18 @@ func GetTrendingOrganizationalUsers(c *fiber.Ctx) error {
 		"users":  usersWithOrganization,
 	})
 }
+
+func GetTrendingHashtags(c *fiber.Ctx) error {
+	paginatedDB := API.Paginator(c)(initializers.DB)
+
+	var hashtags []models.Hashtag
+	if err := API.Order(paginatedDB, API.Trending, API.Hashtags).
+		Find(&hashtags).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":   "success",
+		"hashtags": hashtags,
+	})
+}
@@ -1,49 +1,185 @@
 package controllers
 
 import (
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/cache"
 	"github.com/Pratham-Mishra04/interact/config"
 	"github.com/Pratham-Mishra04/interact/helpers"
 	"github.com/Pratham-Mishra04/interact/initializers"
 	"github.com/Pratham-Mishra04/interact/models"
 	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/utils"
 	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
+	"github.com/Pratham-Mishra04/interact/utils/feed"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 )
 
-func GetFeed(c *fiber.Ctx) error {
-	loggedInUserID := c.GetRespHeader("loggedInUserID")
+type FeedCard struct {
+	Type    feed.ItemType   `json:"type"`
+	Post    *models.Post    `json:"post,omitempty"`
+	Project *models.Project `json:"project,omitempty"`
+	Event   *models.Event   `json:"event,omitempty"`
+	Opening *models.Opening `json:"opening,omitempty"`
+}
+
+// getRankedFeed returns the feed of the user ranked at anchor. The ranking is cached so that
+// the pages of a cursor stay consistent while likes and comments keep changing the scores.
+func getRankedFeed(userID uuid.UUID, anchor time.Time) ([]feed.Item, error) {
+	if items, err := cache.GetFeed(userID.String(), anchor); err == nil {
+		return items, nil
+	}
 
-	var followings []models.FollowFollower
-	if err := initializers.DB.Model(&models.FollowFollower{}).Where("follower_id = ?", loggedInUserID).Find(&followings).Error; err != nil {
-		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	items, err := feed.GatherCandidates(userID, anchor)
+	if err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
-	followingIDs := make([]uuid.UUID, len(followings))
-	for i, following := range followings {
-		followingIDs[i] = following.FollowedID
+	affinities, err := feed.Affinities(userID)
+	if err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
-	paginatedDB := API.Paginator(c)(initializers.DB)
+	items = feed.Rank(items, affinities, anchor)
+
+	go cache.SetFeed(userID.String(), anchor, items)
+
+	return items, nil
+}
+
+func getFeedCards(items []feed.Item, loggedInUserID string) ([]FeedCard, error) {
+	ids := make(map[feed.ItemType][]uuid.UUID)
+	for _, item := range items {
+		ids[item.Type] = append(ids[item.Type], item.ID)
+	}
 
 	var posts []models.Post
-	if err := paginatedDB.
-		Preload("User").
-		Preload("RePost").
-		Preload("RePost.User").
-		Preload("RePost.TaggedUsers").
-		Preload("TaggedUsers").
-		Joins("JOIN users ON posts.user_id = users.id AND users.active = ?", true).
-		Where("user_id = ? OR user_id IN (?)", loggedInUserID, followingIDs).
-		Order("created_at DESC").
-		Find(&posts).Error; err != nil {
-		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	if len(ids[feed.PostItem]) > 0 {
+		if err := initializers.DB.
+			Preload("User").
+			Preload("RePost").
+			Preload("RePost.User").
+			Preload("RePost.TaggedUsers").
+			Preload("TaggedUsers").
+			Preload("Mentions").
+			Preload("LinkPreviews").
+			Preload("Hashtags").
+			Preload("Poll.Options").
+			Scopes(API.VisiblePosts(loggedInUserID)).
+			Where("id IN ? AND status = ?", ids[feed.PostItem], models.PostPublished).
+			Find(&posts).Error; err != nil {
+			return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if err := utils.PreparePolls(posts, loggedInUserID); err != nil {
+			return nil, err
+		}
+	}
+
+	var projects []models.Project
+	if len(ids[feed.ProjectItem]) > 0 {
+		if err := initializers.DB.
+			Preload("User").
+			Preload("Memberships").
+			Where("id IN ? AND is_private = ? AND is_archived = ?", ids[feed.ProjectItem], false, false).
+			Find(&projects).Error; err != nil {
+			return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+	}
+
+	var events []models.Event
+	if len(ids[feed.EventItem]) > 0 {
+		if err := initializers.DB.
+			Preload("Organization").
+			Preload("Organization.User").
+			Where("id IN ?", ids[feed.EventItem]).
+			Find(&events).Error; err != nil {
+			return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+	}
+
+	var openings []models.Opening
+	if len(ids[feed.OpeningItem]) > 0 {
+		if err := initializers.DB.
+			Preload("Project").
+			Preload("User").
+			Where("id IN ? AND active = ?", ids[feed.OpeningItem], true).
+			Find(&openings).Error; err != nil {
+			return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+	}
+
+	cards := make(map[uuid.UUID]FeedCard)
+	for i := range posts {
+		cards[posts[i].ID] = FeedCard{Type: feed.PostItem, Post: &posts[i]}
+	}
+	for i := range projects {
+		cards[projects[i].ID] = FeedCard{Type: feed.ProjectItem, Project: &projects[i]}
+	}
+	for i := range events {
+		cards[events[i].ID] = FeedCard{Type: feed.EventItem, Event: &events[i]}
+	}
+	for i := range openings {
+		cards[openings[i].ID] = FeedCard{Type: feed.OpeningItem, Opening: &openings[i]}
+	}
+
+	//* items deleted or hidden since the feed was ranked are skipped
+	feedCards := make([]FeedCard, 0, len(items))
+	for _, item := range items {
+		if card, ok := cards[item.ID]; ok {
+			feedCards = append(feedCards, card)
+		}
 	}
 
 	go routines.IncrementPostImpression(posts)
+	go routines.IncrementProjectImpression(projects)
+	go routines.IncrementEventImpression(events)
+	go routines.IncrementOpeningImpression(openings)
+
+	return feedCards, nil
+}
+
+func GetFeed(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+	parsedLoggedInUserID, _ := uuid.Parse(loggedInUserID)
+
+	anchor, offset := time.Now(), 0
+	if cursor := c.Query("cursor", ""); cursor != "" {
+		var err error
+		anchor, offset, err = feed.DecodeCursor(cursor)
+		if err != nil {
+			return &fiber.Error{Code: 400, Message: "Invalid Cursor."}
+		}
+	}
+
+	items, err := getRankedFeed(parsedLoggedInUserID, anchor)
+	if err != nil {
+		return err
+	}
+
+	limit := API.CursorLimit(c)
+	if offset > len(items) {
+		offset = len(items)
+	}
+	end := offset + limit
+	if end > len(items) {
+		end = len(items)
+	}
+
+	cards, err := getFeedCards(items[offset:end], loggedInUserID)
+	if err != nil {
+		return err
+	}
+
+	nextCursor := ""
+	if end < len(items) {
+		nextCursor = feed.EncodeCursor(anchor, end)
+	}
 
 	return c.Status(fiber.StatusOK).JSON(fiber.Map{
-		"status": "success",
-		"feed":   posts,
+		"status":     "success",
+		"feed":       cards,
+		"nextCursor": nextCursor,
 	})
 }
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"errors"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func getHashtagFromParams(c *fiber.Ctx) (*models.Hashtag, error) {
+	tag := utils.NormalizeHashtag(c.Params("tag"))
+	if tag == "" {
+		return nil, &fiber.Error{Code: 400, Message: "Invalid Hashtag."}
+	}
+
+	var hashtag models.Hashtag
+	if err := initializers.DB.First(&hashtag, "name = ?", tag).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, &fiber.Error{Code: 400, Message: "No Hashtag of this name found."}
+		}
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return &hashtag, nil
+}
+
+func GetMyFollowedHashtags(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	var follows []models.HashtagFollow
+	if err := initializers.DB.Preload("Hashtag").Where("user_id = ?", loggedInUserID).Order("created_at DESC").Find(&follows).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	hashtags := make([]models.Hashtag, len(follows))
+	for i, follow := range follows {
+		hashtags[i] = follow.Hashtag
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":   "success",
+		"message":  "",
+		"hashtags": hashtags,
+	})
+}
+
+func FollowHashtag(c *fiber.Ctx) error {
+	loggedInUserID, _ := uuid.Parse(c.GetRespHeader("loggedInUserID"))
+
+	hashtag, err := getHashtagFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	var follow models.HashtagFollow
+	if err := initializers.DB.Where("user_id = ? AND hashtag_id = ?", loggedInUserID, hashtag.ID).First(&follow).Error; err == nil {
+		return &fiber.Error{Code: 400, Message: "You are already following this hashtag."}
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	follow = models.HashtagFollow{
+		UserID:    loggedInUserID,
+		HashtagID: hashtag.ID,
+	}
+
+	if err := initializers.DB.Create(&follow).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := initializers.DB.Model(hashtag).UpdateColumn("no_followers", gorm.Expr("no_followers + 1")).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Hashtag followed successfully.",
+	})
+}
+
+func UnfollowHashtag(c *fiber.Ctx) error {
+	loggedInUserID, _ := uuid.Parse(c.GetRespHeader("loggedInUserID"))
+
+	hashtag, err := getHashtagFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	result := initializers.DB.Where("user_id = ? AND hashtag_id = ?", loggedInUserID, hashtag.ID).Delete(&models.HashtagFollow{})
+	if result.Error != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: result.Error.Error(), Err: result.Error}
+	}
+	if result.RowsAffected == 0 {
+		return &fiber.Error{Code: 400, Message: "You do not follow this hashtag."}
+	}
+
+	if err := initializers.DB.Model(hashtag).UpdateColumn("no_followers", gorm.Expr("GREATEST(no_followers - 1, 0)")).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Hashtag unfollowed successfully.",
+	})
+}
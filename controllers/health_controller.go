@@ -0,0 +1,23 @@
+package controllers
+
+import (
+	"github.com/Pratham-Mishra04/interact/utils/mlclient"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetMLHealth reports the state of the circuit breaker and the request metrics of the ML client.
+func GetMLHealth(c *fiber.Ctx) error {
+	stats := mlclient.Default.Stats()
+
+	status := "healthy"
+	if stats.Breaker != mlclient.BreakerClosed {
+		status = "unavailable"
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "",
+		"health":  status,
+		"stats":   stats,
+	})
+}
@@ -0,0 +1,26 @@
+package controllers
+
+import (
+	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/utils/unfurl"
+	"github.com/gofiber/fiber/v2"
+)
+
+// GetLinkPreview builds the preview of a single link, for links added outside of posts and messages (project and event links).
+func GetLinkPreview(c *fiber.Ctx) error {
+	urls := unfurl.ExtractURLs(c.Query("url"), 1)
+	if len(urls) == 0 {
+		return &fiber.Error{Code: 400, Message: "Invalid URL."}
+	}
+
+	preview, err := routines.GetLinkPreview(urls[0])
+	if err != nil || preview.IsEmpty() {
+		return &fiber.Error{Code: 400, Message: "No preview available for this link."}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "",
+		"preview": preview,
+	})
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/Pratham-Mishra04/interact/helpers"
 	"github.com/Pratham-Mishra04/interact/initializers"
 	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -43,6 +45,8 @@ func GetMessages(c *fiber.Ctx) error {
 		Preload("Opening.Project").
 		Preload("Post.User").
 		Preload("Project").
+		Preload("Mentions").
+		Preload("LinkPreviews").
 		Where("chat_id = ? AND created_at > ?", chatID, timestamp).
 		Order("created_at DESC").
 		Find(&messages).Error; err != nil {
@@ -74,6 +78,8 @@ func GetGroupChatMessages(c *fiber.Ctx) error {
 	var messages []models.GroupChatMessage
 	if err := initializers.DB.
 		Preload("User").
+		Preload("Mentions").
+		Preload("LinkPreviews").
 		Where("chat_id = ? AND created_at > ?", chatID, membership.CreatedAt).
 		Order("created_at DESC").
 		Find(&messages).Error; err != nil {
@@ -118,10 +124,16 @@ func AddMessage(c *fiber.Ctx) error {
 		return &fiber.Error{Code: 400, Message: "You have been blocked."}
 	}
 
+	mentions, err := utils.GetMentions(reqBody.Content)
+	if err != nil {
+		return err
+	}
+
 	message := models.Message{
-		UserID:  parsedUserID,
-		Content: reqBody.Content,
-		ChatID:  parsedChatID,
+		UserID:   parsedUserID,
+		Content:  reqBody.Content,
+		ChatID:   parsedChatID,
+		Mentions: mentions,
 	}
 
 	result := initializers.DB.Create(&message)
@@ -136,6 +148,16 @@ func AddMessage(c *fiber.Ctx) error {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
+	//* only the other participant of the chat can be notified of a mention
+	var participantMentions []models.Mention
+	for _, mention := range message.Mentions {
+		if mention.UserID == chat.CreatingUserID || mention.UserID == chat.AcceptingUserID {
+			participantMentions = append(participantMentions, mention)
+		}
+	}
+	go routines.SendMentionNotifications(parsedUserID, participantMentions, 19, nil, nil, nil)
+	go routines.StoreLinkPreviews(models.LinkPreview{MessageID: &message.ID}, message.Content)
+
 	return c.Status(201).JSON(fiber.Map{
 		"status":  "success",
 		"message": message,
@@ -157,17 +179,27 @@ func AddGroupChatMessage(c *fiber.Ctx) error {
 	chatID := reqBody.ChatID
 
 	var membership models.GroupChatMembership
-	if err := initializers.DB.Preload("User").Preload("GroupChat").Where("group_chat_id=? AND user_id = ?", chatID, loggedInUserID).First(&membership).Error; err != nil {
+	if err := initializers.DB.Preload("User").Preload("GroupChat").Preload("GroupChat.Project").Where("group_chat_id=? AND user_id = ?", chatID, loggedInUserID).First(&membership).Error; err != nil {
 		return &fiber.Error{Code: 403, Message: "Do not have the permission to perform this action."}
 	}
 
+	if membership.GroupChat.ProjectID != nil && membership.GroupChat.Project.IsArchived {
+		return &fiber.Error{Code: 403, Message: "The project of this chat is archived."}
+	}
+
 	if membership.GroupChat.AdminOnly && membership.Role == models.ChatMember {
 		return &fiber.Error{Code: 403, Message: "Only admins can send message in this chat."}
 	}
 
+	mentions, err := utils.GetMentions(reqBody.Content)
+	if err != nil {
+		return err
+	}
+
 	message := models.GroupChatMessage{
-		UserID:  parsedUserID,
-		Content: reqBody.Content,
+		UserID:   parsedUserID,
+		Content:  reqBody.Content,
+		Mentions: mentions,
 	}
 
 	parsedChatID, err := uuid.Parse(chatID)
@@ -184,6 +216,26 @@ func AddGroupChatMessage(c *fiber.Ctx) error {
 
 	message.User = membership.User
 
+	//* only members of the group chat can be notified of a mention
+	var memberIDs []uuid.UUID
+	if err := initializers.DB.Model(&models.GroupChatMembership{}).Where("group_chat_id = ?", parsedChatID).Pluck("user_id", &memberIDs).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	isMember := make(map[uuid.UUID]bool)
+	for _, memberID := range memberIDs {
+		isMember[memberID] = true
+	}
+
+	var memberMentions []models.Mention
+	for _, mention := range message.Mentions {
+		if isMember[mention.UserID] {
+			memberMentions = append(memberMentions, mention)
+		}
+	}
+	go routines.SendMentionNotifications(parsedUserID, memberMentions, 19, nil, nil, nil)
+	go routines.StoreLinkPreviews(models.LinkPreview{GroupChatMessageID: &message.ID}, message.Content)
+
 	return c.Status(201).JSON(fiber.Map{
 		"status":  "success",
 		"message": message,
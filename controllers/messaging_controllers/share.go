@@ -9,6 +9,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
 func ShareItem(shareType string) func(c *fiber.Ctx) error {
@@ -31,6 +32,25 @@ func ShareItem(shareType string) func(c *fiber.Ctx) error {
 
 		chats := reqBody.Chats
 
+		//* a shared post is shown to everyone in the chat, so only public posts can be shared
+		if shareType == "post" {
+			parsedPostID, err := uuid.Parse(reqBody.PostID)
+			if err != nil {
+				return &fiber.Error{Code: 400, Message: "Invalid Post ID."}
+			}
+
+			var post models.Post
+			if err := initializers.DB.First(&post, "id = ? AND status = ?", parsedPostID, models.PostPublished).Error; err != nil {
+				if err == gorm.ErrRecordNotFound {
+					return &fiber.Error{Code: 400, Message: "No Post of this ID found."}
+				}
+				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+			}
+			if post.Visibility != models.PostPublic {
+				return &fiber.Error{Code: 400, Message: "Only public posts can be shared."}
+			}
+		}
+
 		for _, chatID := range chats {
 			message := models.Message{
 				UserID:  parsedUserID,
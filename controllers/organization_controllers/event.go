@@ -1,6 +1,7 @@
 package organization_controllers
 
 import (
+	"strings"
 	"time"
 
 	"github.com/Pratham-Mishra04/interact/cache"
@@ -34,6 +35,7 @@ func GetEvent(c *fiber.Ctx) error {
 		Preload("Organization").
 		Preload("Organization.User").
 		Preload("Coordinators").
+		Preload("LinkPreviews").
 		Where("id = ?", eventID).
 		First(&event).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
@@ -104,6 +106,7 @@ func AddEvent(c *fiber.Ctx) error {
 	go routines.MarkOrganizationHistory(parsedOrgID, parsedUserID, 0, nil, nil, &event.ID, nil, nil, "")
 	go routines.IncrementOrgEvent(parsedOrgID)
 	routines.GetImageBlurHash(c, "coverPic", &event)
+	go routines.StoreLinkPreviews(models.LinkPreview{EventID: &event.ID}, strings.Join(event.Links, " "))
 
 	return c.Status(201).JSON(fiber.Map{
 		"status":  "success",
@@ -188,6 +191,9 @@ func UpdateEvent(c *fiber.Ctx) error {
 
 	go routines.MarkOrganizationHistory(parsedOrgID, parsedUserID, 2, nil, nil, &event.ID, nil, nil, "")
 	routines.GetImageBlurHash(c, "coverPic", &event)
+	if reqBody.Links != nil {
+		go routines.StoreLinkPreviews(models.LinkPreview{EventID: &event.ID}, strings.Join(event.Links, " "))
+	}
 	go cache.RemoveEvent(event.ID.String())
 
 	return c.Status(200).JSON(fiber.Map{
@@ -0,0 +1,275 @@
+package controllers
+
+import (
+	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/schemas"
+	"github.com/Pratham-Mishra04/interact/utils"
+	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func getPollOfPublishedPost(pollID string, viewerID string) (models.Poll, models.Post, error) {
+	var poll models.Poll
+	var post models.Post
+
+	parsedPollID, err := uuid.Parse(pollID)
+	if err != nil {
+		return poll, post, &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	if err := initializers.DB.Preload("Options").First(&poll, "id = ?", parsedPollID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return poll, post, &fiber.Error{Code: 400, Message: "No Poll of this ID found."}
+		}
+		return poll, post, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := initializers.DB.First(&post, "id = ? AND status = ?", poll.PostID, models.PostPublished).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return poll, post, &fiber.Error{Code: 400, Message: "No Poll of this ID found."}
+		}
+		return poll, post, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := utils.CheckPostVisibility(&post, viewerID); err != nil {
+		return poll, post, err
+	}
+
+	return poll, post, nil
+}
+
+func updatePollCounts(tx *gorm.DB, pollID uuid.UUID) error {
+	if err := tx.Exec("UPDATE poll_options SET no_votes = (SELECT COUNT(*) FROM poll_votes WHERE poll_votes.poll_option_id = poll_options.id) WHERE poll_id = ?", pollID).Error; err != nil {
+		return err
+	}
+	return tx.Exec("UPDATE polls SET no_voters = (SELECT COUNT(DISTINCT user_id) FROM poll_votes WHERE poll_votes.poll_id = polls.id) WHERE id = ?", pollID).Error
+}
+
+func getPreparedPoll(post models.Post, pollID uuid.UUID, viewerID string) (*models.Poll, error) {
+	var poll models.Poll
+	if err := initializers.DB.Preload("Options").First(&poll, "id = ?", pollID).Error; err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	post.Poll = &poll
+	if err := utils.PreparePostPoll(&post, viewerID); err != nil {
+		return nil, err
+	}
+	return post.Poll, nil
+}
+
+func GetPoll(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	poll, post, err := getPollOfPublishedPost(c.Params("pollID"), loggedInUserID)
+	if err != nil {
+		return err
+	}
+
+	preparedPoll, err := getPreparedPoll(post, poll.ID, loggedInUserID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "",
+		"poll":    preparedPoll,
+	})
+}
+
+func VotePoll(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+	parsedLoggedInUserID, _ := uuid.Parse(loggedInUserID)
+
+	var reqBody schemas.PollVoteSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.PollVoteSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	poll, post, err := getPollOfPublishedPost(c.Params("pollID"), loggedInUserID)
+	if err != nil {
+		return err
+	}
+
+	if poll.IsClosed() {
+		return &fiber.Error{Code: 400, Message: "This poll has ended."}
+	}
+
+	if !poll.IsMultiChoice && len(reqBody.OptionIDs) > 1 {
+		return &fiber.Error{Code: 400, Message: "Only one option can be selected in this poll."}
+	}
+
+	pollOptions := make(map[string]bool)
+	for _, option := range poll.Options {
+		pollOptions[option.ID.String()] = true
+	}
+
+	var votes []models.PollVote
+	for _, optionID := range reqBody.OptionIDs {
+		if !pollOptions[optionID] {
+			return &fiber.Error{Code: 400, Message: "Invalid Poll Option."}
+		}
+		votes = append(votes, models.PollVote{
+			PollID:        poll.ID,
+			PollOptionID:  uuid.MustParse(optionID),
+			UserID:        parsedLoggedInUserID,
+			IsMultiChoice: poll.IsMultiChoice,
+		})
+	}
+
+	tx := initializers.DB.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	defer func() {
+		if tx.Error != nil {
+			tx.Rollback()
+			go helpers.LogDatabaseError("Transaction rolled back due to error", tx.Error, "VotePoll")
+		}
+	}()
+
+	//* voting again replaces the previous vote
+	if err := tx.Where("poll_id = ? AND user_id = ?", poll.ID, parsedLoggedInUserID).Delete(&models.PollVote{}).Error; err != nil {
+		tx.Rollback()
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := tx.Create(&votes).Error; err != nil {
+		tx.Rollback()
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := updatePollCounts(tx, poll.ID); err != nil {
+		tx.Rollback()
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go cache.RemovePost(post.ID.String())
+
+	preparedPoll, err := getPreparedPoll(post, poll.ID, loggedInUserID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Vote recorded",
+		"poll":    preparedPoll,
+	})
+}
+
+func RemovePollVote(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	poll, post, err := getPollOfPublishedPost(c.Params("pollID"), loggedInUserID)
+	if err != nil {
+		return err
+	}
+
+	if poll.IsClosed() {
+		return &fiber.Error{Code: 400, Message: "This poll has ended."}
+	}
+
+	tx := initializers.DB.Begin()
+	if tx.Error != nil {
+		return tx.Error
+	}
+
+	defer func() {
+		if tx.Error != nil {
+			tx.Rollback()
+			go helpers.LogDatabaseError("Transaction rolled back due to error", tx.Error, "RemovePollVote")
+		}
+	}()
+
+	result := tx.Where("poll_id = ? AND user_id = ?", poll.ID, loggedInUserID).Delete(&models.PollVote{})
+	if result.Error != nil {
+		tx.Rollback()
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: result.Error.Error(), Err: result.Error}
+	}
+	if result.RowsAffected == 0 {
+		tx.Rollback()
+		return &fiber.Error{Code: 400, Message: "You have not voted in this poll."}
+	}
+
+	if err := updatePollCounts(tx, poll.ID); err != nil {
+		tx.Rollback()
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := tx.Commit().Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go cache.RemovePost(post.ID.String())
+
+	preparedPoll, err := getPreparedPoll(post, poll.ID, loggedInUserID)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Vote removed",
+		"poll":    preparedPoll,
+	})
+}
+
+func GetPollVoters(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	poll, post, err := getPollOfPublishedPost(c.Params("pollID"), loggedInUserID)
+	if err != nil {
+		return err
+	}
+
+	if poll.IsAnonymous {
+		return &fiber.Error{Code: 400, Message: "Votes of this poll are anonymous."}
+	}
+
+	preparedPoll, err := getPreparedPoll(post, poll.ID, loggedInUserID)
+	if err != nil {
+		return err
+	}
+	if preparedPoll.ResultsHidden {
+		return &fiber.Error{Code: 403, Message: "Vote in this poll to see the results."}
+	}
+
+	paginatedDB := API.Paginator(c)(initializers.DB)
+
+	db := paginatedDB.Preload("User").Where("poll_id = ?", poll.ID)
+	if optionID := c.Query("optionID", ""); optionID != "" {
+		parsedOptionID, err := uuid.Parse(optionID)
+		if err != nil {
+			return &fiber.Error{Code: 400, Message: "Invalid Poll Option."}
+		}
+		db = db.Where("poll_option_id = ?", parsedOptionID)
+	}
+
+	var votes []models.PollVote
+	if err := db.Order("created_at DESC").Find(&votes).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "",
+		"votes":   votes,
+	})
+}
@@ -1,6 +1,9 @@
 package controllers
 
 import (
+	"strings"
+	"time"
+
 	"github.com/Pratham-Mishra04/interact/cache"
 	"github.com/Pratham-Mishra04/interact/config"
 	"github.com/Pratham-Mishra04/interact/helpers"
@@ -13,6 +16,7 @@ import (
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 func GetPost(c *fiber.Ctx) error {
@@ -21,6 +25,13 @@ func GetPost(c *fiber.Ctx) error {
 	postInCache, err := cache.GetPost(postID)
 
 	if err == nil {
+		if err := utils.CheckPostVisibility(postInCache, c.GetRespHeader("loggedInUserID")); err != nil {
+			return err
+		}
+		if err := utils.PreparePostPoll(postInCache, c.GetRespHeader("loggedInUserID")); err != nil {
+			return err
+		}
+
 		return c.Status(200).JSON(fiber.Map{
 			"status":  "success",
 			"message": "",
@@ -34,14 +45,36 @@ func GetPost(c *fiber.Ctx) error {
 	}
 
 	var post models.Post
-	if err := initializers.DB.Preload("RePost").Preload("User").First(&post, "id = ?", parsedPostID).Error; err != nil {
+	if err := initializers.DB.Preload("RePost").Preload("User").Preload("Mentions").Preload("LinkPreviews").Preload("Hashtags").Preload("Poll.Options").First(&post, "id = ?", parsedPostID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return &fiber.Error{Code: 400, Message: "No Post of this ID found."}
 		}
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
-	go cache.SetPost(postID, &post)
+	if post.Status != models.PostPublished {
+		//* drafts of an organization are read by its senior members through the org route, which sets the owner as the logged in user
+		if post.UserID.String() != c.GetRespHeader("loggedInUserID") {
+			return &fiber.Error{Code: 400, Message: "No Post of this ID found."}
+		}
+
+		return c.Status(200).JSON(fiber.Map{
+			"status":  "success",
+			"message": "",
+			"post":    post,
+		})
+	}
+
+	//* cached before the poll is prepared for the viewer, so the cached post stays the same for everyone
+	cache.SetPost(postID, &post)
+
+	if err := utils.CheckPostVisibility(&post, c.GetRespHeader("loggedInUserID")); err != nil {
+		return err
+	}
+
+	if err := utils.PreparePostPoll(&post, c.GetRespHeader("loggedInUserID")); err != nil {
+		return err
+	}
 
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
@@ -61,12 +94,21 @@ func GetUserPosts(c *fiber.Ctx) error {
 		Preload("RePost.User").
 		Preload("User").
 		Preload("TaggedUsers").
-		Where("user_id = ?", userID).
+		Preload("Mentions").
+		Preload("LinkPreviews").
+		Preload("Hashtags").
+		Preload("Poll.Options").
+		Scopes(API.VisiblePosts(c.GetRespHeader("loggedInUserID"))).
+		Where("user_id = ? AND status = ?", userID, models.PostPublished).
 		Order("created_at DESC").
 		Find(&posts).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
+	if err := utils.PreparePolls(posts, c.GetRespHeader("loggedInUserID")); err != nil {
+		return err
+	}
+
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
 		"message": "",
@@ -84,11 +126,19 @@ func GetMyPosts(c *fiber.Ctx) error {
 		Preload("RePost").
 		Preload("RePost.User").
 		Preload("TaggedUsers").
+		Preload("Mentions").
+		Preload("LinkPreviews").
+		Preload("Hashtags").
+		Preload("Poll.Options").
 		Preload("RePost.TaggedUsers").
-		Where("user_id = ?", loggedInUserID).Find(&posts).Error; err != nil {
+		Where("user_id = ? AND status = ?", loggedInUserID, models.PostPublished).Find(&posts).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
+	if err := utils.PreparePolls(posts, loggedInUserID); err != nil {
+		return err
+	}
+
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
 		"message": "",
@@ -96,6 +146,47 @@ func GetMyPosts(c *fiber.Ctx) error {
 	})
 }
 
+func GetPostReposts(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	parsedPostID, err := uuid.Parse(c.Params("postID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	if err := utils.CheckPostIDVisibility(parsedPostID.String(), loggedInUserID); err != nil {
+		return err
+	}
+
+	paginatedDB := API.Paginator(c)(initializers.DB)
+
+	db := paginatedDB.
+		Preload("User").
+		Preload("Mentions").
+		Preload("LinkPreviews").
+		Preload("Hashtags").
+		Scopes(API.VisiblePosts(loggedInUserID)).
+		Where("re_post_id = ? AND status = ?", parsedPostID, models.PostPublished)
+
+	switch c.Query("type", "") {
+	case "quotes":
+		db = db.Where("content <> ''")
+	case "plain":
+		db = db.Where("content = ''")
+	}
+
+	var posts []models.Post
+	if err := db.Order("created_at DESC").Find(&posts).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "",
+		"reposts": posts,
+	})
+}
+
 func GetMyLikedPosts(c *fiber.Ctx) error {
 	loggedInUserID := c.GetRespHeader("loggedInUserID")
 
@@ -139,6 +230,18 @@ func AddPost(c *fiber.Ctx) error {
 		return &fiber.Error{Code: 400, Message: err.Error()}
 	}
 
+	var rePostID *uuid.UUID
+	if reqBody.RePostID != "" {
+		if reqBody.Content == "" && len(reqBody.PollOptions) > 0 {
+			return &fiber.Error{Code: 400, Message: "Add some content to the post along with the poll."}
+		}
+
+		rePostID, err = getRePostID(reqBody.RePostID, parsedID, reqBody.Content != "")
+		if err != nil {
+			return err
+		}
+	}
+
 	// images, err := utils.SaveMultipleFiles(c, "images", "post", true, 1280, 720)
 	images, err := utils.UploadMultipleImages(c, "images", helpers.PostClient, 1280, 720)
 	if err != nil {
@@ -146,31 +249,72 @@ func AddPost(c *fiber.Ctx) error {
 	}
 
 	newPost := models.Post{
-		UserID:  parsedID,
-		Content: reqBody.Content,
-		Images:  images,
-		Tags:    reqBody.Tags,
+		UserID:   parsedID,
+		Content:  reqBody.Content,
+		Images:   images,
+		Tags:     reqBody.Tags,
+		Status:   models.PostPublished,
+		RePostID: rePostID,
 	}
 
-	if reqBody.RePostID != "" {
-		parsedRePostID, err := uuid.Parse(reqBody.RePostID)
+	if reqBody.ScheduledFor != "" {
+		scheduledFor, err := parseScheduledFor(reqBody.ScheduledFor)
 		if err != nil {
-			return &fiber.Error{Code: 400, Message: "Invalid Post ID in rePost"}
+			return err
 		}
-		newPost.RePostID = &parsedRePostID
+		newPost.Status = models.PostScheduled
+		newPost.ScheduledFor = &scheduledFor
+	} else if reqBody.IsDraft {
+		newPost.Status = models.PostDraft
 	}
 
-	if reqBody.TaggedUsernames != nil {
-		var taggedUsers []models.User
+	orgMemberID := c.GetRespHeader("orgMemberID")
+	orgID := c.Params("orgID")
+	if orgMemberID != "" && orgID != "" {
+		parsedOrgID, err := uuid.Parse(orgID)
+		if err != nil {
+			return &fiber.Error{Code: 400, Message: "Invalid Organization ID."}
+		}
 
-		for _, username := range reqBody.TaggedUsernames {
-			var user models.User
-			if err := initializers.DB.First(&user, "username=?", username).Error; err == nil {
-				taggedUsers = append(taggedUsers, user)
-			}
+		parsedOrgMemberID, err := uuid.Parse(orgMemberID)
+		if err != nil {
+			return &fiber.Error{Code: 400, Message: "Invalid User ID."}
 		}
+		newPost.OrganizationID = &parsedOrgID
+		newPost.OrgMemberID = &parsedOrgMemberID
+	}
 
-		newPost.TaggedUsers = taggedUsers
+	if reqBody.Visibility != "" {
+		newPost.Visibility = models.PostVisibility(reqBody.Visibility)
+		if newPost.Visibility == models.PostOrgMembers && newPost.OrganizationID == nil {
+			return &fiber.Error{Code: 400, Message: "Only organizations can make posts for their members."}
+		}
+	}
+
+	mentions, err := utils.GetMentions(reqBody.Content)
+	if err != nil {
+		return err
+	}
+	newPost.Mentions = mentions
+
+	taggedUsers, err := getTaggedUsers(reqBody.TaggedUsernames, mentions)
+	if err != nil {
+		return err
+	}
+	newPost.TaggedUsers = taggedUsers
+
+	hashtags, err := utils.GetHashtags(reqBody.Content)
+	if err != nil {
+		return err
+	}
+	newPost.Hashtags = hashtags
+
+	if len(reqBody.PollOptions) > 0 {
+		poll, err := newPoll(reqBody)
+		if err != nil {
+			return err
+		}
+		newPost.Poll = poll
 	}
 
 	result := initializers.DB.Create(&newPost)
@@ -182,28 +326,20 @@ func AddPost(c *fiber.Ctx) error {
 		Preload("RePost").
 		Preload("RePost.User").
 		Preload("TaggedUsers").
+		Preload("Mentions").
+		Preload("LinkPreviews").
+		Preload("Hashtags").
+		Preload("Poll.Options").
 		First(&newPost).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
-	orgMemberID := c.GetRespHeader("orgMemberID")
-	orgID := c.Params("orgID")
-	if orgMemberID != "" && orgID != "" {
-		parsedOrgID, err := uuid.Parse(orgID)
-		if err != nil {
-			return &fiber.Error{Code: 400, Message: "Invalid Organization ID."}
-		}
-
-		parsedOrgMemberID, err := uuid.Parse(orgMemberID)
-		if err != nil {
-			return &fiber.Error{Code: 400, Message: "Invalid User ID."}
-		}
-		go routines.MarkOrganizationHistory(parsedOrgID, parsedOrgMemberID, 6, &newPost.ID, nil, nil, nil, nil, "")
-	}
-	if reqBody.RePostID != "" {
-		go routines.IncrementReposts(*newPost.RePostID)
+	if newPost.Status == models.PostPublished {
+		go routines.HandlePostPublished(newPost)
 	}
 
+	go routines.StoreLinkPreviews(models.LinkPreview{PostID: &newPost.ID}, newPost.Content)
+
 	return c.Status(201).JSON(fiber.Map{
 		"status":  "success",
 		"message": "Post Added",
@@ -221,7 +357,7 @@ func UpdatePost(c *fiber.Ctx) error {
 	}
 
 	var post models.Post
-	if err := initializers.DB.Preload("User").Preload("TaggedUsers").First(&post, "id = ? and user_id=?", parsedPostID, loggedInUserID).Error; err != nil {
+	if err := initializers.DB.Preload("User").Preload("TaggedUsers").Preload("Mentions").Preload("Hashtags").First(&post, "id = ? and user_id=?", parsedPostID, loggedInUserID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return &fiber.Error{Code: 400, Message: "No Post of this ID found."}
 		}
@@ -233,49 +369,127 @@ func UpdatePost(c *fiber.Ctx) error {
 		return &fiber.Error{Code: 400, Message: "Invalid Request Body."}
 	}
 
+	contentChanged := reqBody.Content != "" && reqBody.Content != post.Content
+	oldContent := post.Content
+
+	//* drafts are not visible to anyone else, so only the edits of published posts are kept
+	keepRevision := contentChanged && post.Status == models.PostPublished
+
 	if reqBody.Content != "" {
 		post.Content = reqBody.Content
 	}
 	if reqBody.Tags != nil {
 		post.Tags = *reqBody.Tags
 	}
+	if reqBody.Visibility != nil {
+		switch visibility := models.PostVisibility(*reqBody.Visibility); visibility {
+		case models.PostPublic, models.PostFollowers, models.PostMentioned, models.PostOrgMembers:
+			post.Visibility = visibility
+		default:
+			return &fiber.Error{Code: 400, Message: "Invalid Visibility."}
+		}
+		if post.Visibility == models.PostOrgMembers && post.OrganizationID == nil {
+			return &fiber.Error{Code: 400, Message: "Only organizations can make posts for their members."}
+		}
 
-	post.Edited = true
+		//* reposts show the original to everyone who can see them, so a reposted post has to stay public
+		if post.Visibility != models.PostPublic {
+			var count int64
+			if err := initializers.DB.Model(&models.Post{}).Where("re_post_id = ?", post.ID).Count(&count).Error; err != nil {
+				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+			}
+			if count > 0 {
+				return &fiber.Error{Code: 400, Message: "Posts which have been reposted cannot be made private."}
+			}
+		}
+	}
 
-	var newTaggedUsers []models.User
-	var usersToRemove []models.User
+	isPublished := post.Status == models.PostPublished
+	if isPublished {
+		post.Edited = true
+	} else if reqBody.ScheduledFor != nil {
+		if *reqBody.ScheduledFor == "" {
+			post.Status = models.PostDraft
+			post.ScheduledFor = nil
+		} else {
+			scheduledFor, err := parseScheduledFor(*reqBody.ScheduledFor)
+			if err != nil {
+				return err
+			}
+			post.Status = models.PostScheduled
+			post.ScheduledFor = &scheduledFor
+		}
+	}
 
-	if reqBody.TaggedUsernames != nil { //TODO not working
-		// Create a map to store existing tagged users for quick comparison
-		existingTaggedUsers := make(map[uuid.UUID]models.User)
-		for _, user := range post.TaggedUsers {
-			existingTaggedUsers[user.ID] = user
+	oldMentions := post.Mentions
+	if contentChanged {
+		mentions, err := utils.GetMentions(post.Content)
+		if err != nil {
+			return err
 		}
+		post.Mentions = mentions
+	}
 
-		for _, username := range reqBody.TaggedUsernames {
-			var user models.User
-			if err := initializers.DB.First(&user, "username=?", username).Error; err == nil {
-				newTaggedUsers = append(newTaggedUsers, user)
+	taggedUsers := post.TaggedUsers
+	if reqBody.TaggedUsernames != nil || contentChanged {
+		usernames := reqBody.TaggedUsernames
+		if usernames == nil {
+			for _, user := range post.TaggedUsers {
+				usernames = append(usernames, user.Username)
 			}
 		}
 
-		// Compare and find users to remove
-		for _, existingUser := range post.TaggedUsers {
-			if _, exists := existingTaggedUsers[existingUser.ID]; !exists {
-				usersToRemove = append(usersToRemove, existingUser)
+		taggedUsers, err = getTaggedUsers(usernames, post.Mentions)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if keepRevision {
+			if err := saveRevision(tx, models.Revision{PostID: &post.ID}, oldContent, post.CreatedAt); err != nil {
+				return err
 			}
 		}
 
-		// Update the TaggedUsers with new users
-		post.TaggedUsers = newTaggedUsers
+		if err := tx.Omit("TaggedUsers", "Mentions", "Hashtags").Save(&post).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		return nil
+	}); err != nil {
+		return err
 	}
 
-	if err := initializers.DB.Save(&post).Error; err != nil {
+	if err := initializers.DB.Model(&post).Association("TaggedUsers").Replace(taggedUsers); err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
+	post.TaggedUsers = taggedUsers
+
+	if contentChanged {
+		if err := replaceMentions(models.Mention{PostID: &post.ID}, post.Mentions); err != nil {
+			return err
+		}
+		if isPublished {
+			go routines.SendMentionNotifications(post.UserID, newMentions(oldMentions, post.Mentions), 17, &post.ID, nil, nil)
+		}
+
+		go routines.StoreLinkPreviews(models.LinkPreview{PostID: &post.ID}, post.Content)
+
+		hashtags, err := utils.GetHashtags(post.Content)
+		if err != nil {
+			return err
+		}
+
+		if err := initializers.DB.Model(&post).Association("Hashtags").Replace(hashtags); err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
 
-	for _, userToRemove := range usersToRemove {
-		initializers.DB.Model(&post).Association("TaggedUsers").Delete(userToRemove)
+		if isPublished {
+			addedHashtags, removedHashtags := routines.HashtagsDiff(post.Hashtags, hashtags)
+			go routines.IncrementHashtagPosts(addedHashtags)
+			go routines.DecrementHashtagPosts(removedHashtags)
+		}
+		post.Hashtags = hashtags
 	}
 
 	orgMemberID := c.GetRespHeader("orgMemberID")
@@ -302,8 +516,78 @@ func UpdatePost(c *fiber.Ctx) error {
 	})
 }
 
+func GetMyDraftPosts(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	paginatedDB := API.Paginator(c)(initializers.DB)
+
+	var posts []models.Post
+	if err := paginatedDB.Preload("User").
+		Preload("RePost").
+		Preload("RePost.User").
+		Preload("TaggedUsers").
+		Preload("Mentions").
+		Preload("LinkPreviews").
+		Preload("Hashtags").
+		Preload("Poll.Options").
+		Where("user_id = ? AND status <> ?", loggedInUserID, models.PostPublished).
+		Order("scheduled_for ASC NULLS LAST, created_at DESC").
+		Find(&posts).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "",
+		"posts":   posts,
+	})
+}
+
+func PublishPost(c *fiber.Ctx) error {
+	postID := c.Params("postID")
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	parsedPostID, err := uuid.Parse(postID)
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var post models.Post
+	if err := initializers.DB.Preload("Mentions").Preload("Hashtags").First(&post, "id = ? AND user_id = ? AND status <> ?", parsedPostID, loggedInUserID, models.PostPublished).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Draft of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	//* the member publishing an org post is the one recorded in the history
+	if orgMemberID := c.GetRespHeader("orgMemberID"); orgMemberID != "" {
+		parsedOrgMemberID, err := uuid.Parse(orgMemberID)
+		if err != nil {
+			return &fiber.Error{Code: 400, Message: "Invalid User ID."}
+		}
+		post.OrgMemberID = &parsedOrgMemberID
+	}
+
+	post.Status = models.PostPublished
+	post.ScheduledFor = nil
+	post.CreatedAt = time.Now()
+
+	if err := initializers.DB.Omit(clause.Associations).Save(&post).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go routines.HandlePostPublished(post)
+	go cache.RemovePost(postID)
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Post published successfully",
+		"post":    post,
+	})
+}
+
 func DeletePost(c *fiber.Ctx) error {
-	//TODO Handle what happens when the post to be deleted is a post of a repost
 	postID := c.Params("postID")
 	loggedInUserID := c.GetRespHeader("loggedInUserID")
 
@@ -313,7 +597,7 @@ func DeletePost(c *fiber.Ctx) error {
 	}
 
 	var post models.Post
-	if err := initializers.DB.Preload("User").First(&post, "id = ? AND user_id=?", parsedPostID, loggedInUserID).Error; err != nil {
+	if err := initializers.DB.Preload("User").Preload("Hashtags").First(&post, "id = ? AND user_id=?", parsedPostID, loggedInUserID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return &fiber.Error{Code: 400, Message: "No Post of this ID found."}
 		}
@@ -342,10 +626,37 @@ func DeletePost(c *fiber.Ctx) error {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
+	if err := initializers.DB.Model(&post).Association("Hashtags").Clear(); err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	if post.Status == models.PostPublished {
+		go routines.DecrementHashtagPosts(post.Hashtags)
+	}
+
+	//* reposts of this post are kept, showing that the original was removed
+	var rePostIDs []uuid.UUID
+	if err := initializers.DB.Model(&models.Post{}).Where("re_post_id = ?", post.ID).Pluck("id", &rePostIDs).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if len(rePostIDs) > 0 {
+		if err := initializers.DB.Model(&models.Post{}).Where("id IN ?", rePostIDs).Updates(map[string]any{"re_post_id": nil, "re_post_removed": true}).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+	}
+
 	if err := initializers.DB.Delete(&post).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
+	for _, rePostID := range rePostIDs {
+		go cache.RemovePost(rePostID.String())
+	}
+	if post.RePostID != nil && post.Status == models.PostPublished {
+		go routines.DecrementReposts(*post.RePostID)
+	}
+	go cache.RemovePost(postID)
+
 	orgMemberID := c.GetRespHeader("orgMemberID")
 	orgID := c.Params("orgID")
 	if orgMemberID != "" && orgID != "" {
@@ -366,3 +677,149 @@ func DeletePost(c *fiber.Ctx) error {
 		"message": "Post deleted successfully",
 	})
 }
+
+func getTaggedUsers(usernames []string, mentions []models.Mention) ([]models.User, error) {
+	var mentionedUserIDs []uuid.UUID
+	for _, mention := range mentions {
+		mentionedUserIDs = append(mentionedUserIDs, mention.UserID)
+	}
+
+	if len(usernames) == 0 && len(mentionedUserIDs) == 0 {
+		return nil, nil
+	}
+
+	var users []models.User
+	if err := initializers.DB.
+		Where("active = ?", true).
+		Where("username IN ? OR id IN ?", usernames, mentionedUserIDs).
+		Find(&users).Error; err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return users, nil
+}
+
+// replaceMentions swaps the stored mentions of the parent referenced by owner with mentions.
+func replaceMentions(owner models.Mention, mentions []models.Mention) error {
+	if err := initializers.DB.Where(&owner).Delete(&models.Mention{}).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	for i := range mentions {
+		mentions[i].PostID = owner.PostID
+		mentions[i].CommentID = owner.CommentID
+	}
+
+	if len(mentions) > 0 {
+		if err := initializers.DB.Create(&mentions).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+	}
+
+	return nil
+}
+
+// newMentions returns the mentions of users that were not mentioned before an edit.
+func newMentions(oldMentions []models.Mention, mentions []models.Mention) []models.Mention {
+	mentionedBefore := make(map[uuid.UUID]bool)
+	for _, mention := range oldMentions {
+		mentionedBefore[mention.UserID] = true
+	}
+
+	var filtered []models.Mention
+	for _, mention := range mentions {
+		if !mentionedBefore[mention.UserID] {
+			filtered = append(filtered, mention)
+		}
+	}
+	return filtered
+}
+
+func parseScheduledFor(value string) (time.Time, error) {
+	scheduledFor, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, &fiber.Error{Code: 400, Message: "Invalid Schedule Time."}
+	}
+	if scheduledFor.Before(time.Now()) {
+		return time.Time{}, &fiber.Error{Code: 400, Message: "Schedule Time must be in the future."}
+	}
+	return scheduledFor, nil
+}
+
+// getRePostID checks that the post being reposted can be reposted by the user.
+// Plain reposts of a plain repost point to its original, while quotes are kept in the chain.
+func getRePostID(rePostID string, userID uuid.UUID, isQuote bool) (*uuid.UUID, error) {
+	parsedRePostID, err := uuid.Parse(rePostID)
+	if err != nil {
+		return nil, &fiber.Error{Code: 400, Message: "Invalid Post ID in rePost"}
+	}
+
+	var rePost models.Post
+	if err := initializers.DB.First(&rePost, "id = ? AND status = ?", parsedRePostID, models.PostPublished).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, &fiber.Error{Code: 400, Message: "No Post of this ID found."}
+		}
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if rePost.Content == "" {
+		if rePost.RePostID == nil {
+			return nil, &fiber.Error{Code: 400, Message: "The original post has been removed."}
+		}
+		if err := initializers.DB.First(&rePost, "id = ?", *rePost.RePostID).Error; err != nil {
+			return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+	}
+
+	if routines.IsBlocked(userID, rePost.UserID) {
+		return nil, &fiber.Error{Code: 403, Message: "You cannot repost this post."}
+	}
+
+	//* a repost shows the original to everyone who can see the repost
+	if rePost.Visibility != models.PostPublic {
+		return nil, &fiber.Error{Code: 400, Message: "Only public posts can be reposted."}
+	}
+
+	if !isQuote {
+		var count int64
+		if err := initializers.DB.Model(&models.Post{}).Where("user_id = ? AND re_post_id = ? AND content = ''", userID, rePost.ID).Count(&count).Error; err != nil {
+			return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		if count > 0 {
+			return nil, &fiber.Error{Code: 400, Message: "You have already reposted this post."}
+		}
+	}
+
+	return &rePost.ID, nil
+}
+
+func newPoll(reqBody schemas.PostCreateSchema) (*models.Poll, error) {
+	poll := models.Poll{
+		IsMultiChoice: reqBody.PollMultiChoice,
+		IsAnonymous:   reqBody.PollAnonymous,
+	}
+
+	if reqBody.PollEndsAt != "" {
+		endsAt, err := time.Parse(time.RFC3339, reqBody.PollEndsAt)
+		if err != nil {
+			return nil, &fiber.Error{Code: 400, Message: "Invalid Poll End Time."}
+		}
+		if endsAt.Before(time.Now()) {
+			return nil, &fiber.Error{Code: 400, Message: "Poll End Time must be in the future."}
+		}
+		poll.EndsAt = &endsAt
+	}
+
+	for i, content := range reqBody.PollOptions {
+		content = strings.TrimSpace(content)
+		if content == "" {
+			return nil, &fiber.Error{Code: 400, Message: "Poll Options cannot be empty."}
+		}
+		poll.Options = append(poll.Options, models.PollOption{
+			Content:  content,
+			Position: i,
+		})
+	}
+
+	return &poll, nil
+}
@@ -0,0 +1,195 @@
+package project_controllers
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// parseActivityTime reads a date or a full timestamp from the query.
+func parseActivityTime(value string) (time.Time, bool, error) {
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, false, nil
+	}
+	parsed, err := time.Parse("2006-01-02", value)
+	return parsed, true, err
+}
+
+// filterProjectActivity applies the actor, type, from and to query params to the history of the project.
+func filterProjectActivity(c *fiber.Ctx, db *gorm.DB) (*gorm.DB, error) {
+	db = db.Where("project_histories.project_id = ?", c.Params("projectID"))
+
+	if actor := c.Query("actor"); actor != "" {
+		parsedActorID, err := uuid.Parse(actor)
+		if err != nil {
+			return nil, &fiber.Error{Code: 400, Message: "Invalid Actor ID."}
+		}
+		db = db.Where("project_histories.sender_id = ?", parsedActorID)
+	}
+
+	if types := c.Query("type"); types != "" {
+		var historyTypes []int
+		for _, name := range strings.Split(types, ",") {
+			historyType, ok := models.ProjectHistoryTypeFromName(strings.TrimSpace(name))
+			if !ok {
+				return nil, &fiber.Error{Code: 400, Message: fmt.Sprintf("Invalid Activity Type: %s.", name)}
+			}
+			historyTypes = append(historyTypes, historyType)
+		}
+		db = db.Where("project_histories.history_type IN ?", historyTypes)
+	}
+
+	from, _, err := parseActivityTime(c.Query("from"))
+	if err != nil {
+		return nil, &fiber.Error{Code: 400, Message: "Invalid From Date."}
+	}
+	if !from.IsZero() {
+		db = db.Where("project_histories.created_at >= ?", from)
+	}
+
+	to, isDate, err := parseActivityTime(c.Query("to"))
+	if err != nil {
+		return nil, &fiber.Error{Code: 400, Message: "Invalid To Date."}
+	}
+	if !to.IsZero() {
+		if isDate {
+			to = to.AddDate(0, 0, 1) //* a date includes the whole day
+		}
+		db = db.Where("project_histories.created_at < ?", to)
+	}
+
+	return db, nil
+}
+
+// csvCell stops spreadsheet apps from reading user written text, like names, as a formula.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsAny(value[:1], "=+-@") {
+		return "'" + value
+	}
+	return value
+}
+
+func preloadProjectActivity(db *gorm.DB) *gorm.DB {
+	return db.Preload("Sender").Preload("User").Preload("Opening").Preload("Task").Preload("Milestone")
+}
+
+// GetProjectActivity gets the typed and summarised history of the project, newest first, with cursor pagination.
+func GetProjectActivity(c *fiber.Ctx) error {
+	filteredDB, err := filterProjectActivity(c, initializers.DB)
+	if err != nil {
+		return err
+	}
+
+	cursoredDB := API.CursorPaginator(c, "project_histories", false)(filteredDB)
+
+	var activity []models.ProjectHistory
+	if err := preloadProjectActivity(cursoredDB).Find(&activity).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	for i := range activity {
+		activity[i].SetActivity()
+	}
+
+	nextCursor := ""
+	if len(activity) > 0 {
+		last := activity[len(activity)-1]
+		nextCursor = API.NextCursor(len(activity), API.CursorLimit(c), last.CreatedAt, last.ID)
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":     "success",
+		"activity":   activity,
+		"nextCursor": nextCursor,
+	})
+}
+
+type projectActivityExport struct {
+	Time          time.Time `json:"time"`
+	Type          string    `json:"type"`
+	ActorName     string    `json:"actorName"`
+	ActorUsername string    `json:"actorUsername"`
+	Summary       string    `json:"summary"`
+}
+
+// ExportProjectActivity downloads the filtered history of the project as csv or json, oldest first, for retrospectives.
+func ExportProjectActivity(c *fiber.Ctx) error {
+	format := c.Query("format", "csv")
+	if format != "csv" && format != "json" {
+		return &fiber.Error{Code: 400, Message: "Format can only be csv or json."}
+	}
+
+	var project models.Project
+	if err := initializers.DB.Select("id", "slug").First(&project, "id = ?", c.Params("projectID")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Project of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	filteredDB, err := filterProjectActivity(c, initializers.DB)
+	if err != nil {
+		return err
+	}
+
+	var activity []models.ProjectHistory
+	if err := preloadProjectActivity(filteredDB).
+		Order("project_histories.created_at ASC, project_histories.id ASC").
+		Limit(config.MAX_ACTIVITY_EXPORT_ROWS).
+		Find(&activity).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	rows := make([]projectActivityExport, len(activity))
+	for i := range activity {
+		activity[i].SetActivity()
+		rows[i] = projectActivityExport{
+			Time:          activity[i].CreatedAt,
+			Type:          activity[i].Type,
+			ActorName:     activity[i].Sender.Name,
+			ActorUsername: activity[i].Sender.Username,
+			Summary:       activity[i].Summary,
+		}
+	}
+
+	var body []byte
+	if format == "json" {
+		body, err = json.Marshal(rows)
+		if err != nil {
+			return helpers.AppError{Code: 500, Message: config.SERVER_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	} else {
+		var buffer bytes.Buffer
+		writer := csv.NewWriter(&buffer)
+		_ = writer.Write([]string{"time", "type", "actor_name", "actor_username", "summary"})
+		for _, row := range rows {
+			_ = writer.Write([]string{row.Time.Format(time.RFC3339), row.Type, csvCell(row.ActorName), csvCell(row.ActorUsername), csvCell(row.Summary)})
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return helpers.AppError{Code: 500, Message: config.SERVER_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		body = buffer.Bytes()
+		c.Set(fiber.HeaderContentType, "text/csv")
+	}
+
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s-activity.%s"`, project.Slug, format))
+
+	return c.Status(200).Send(body)
+}
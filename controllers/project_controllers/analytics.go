@@ -0,0 +1,233 @@
+package project_controllers
+
+import (
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const analyticsDateLayout = "2006-01-02"
+
+type analyticsBucket struct {
+	Date time.Time `json:"date"`
+	models.ProjectAnalyticsMetrics
+}
+
+type analyticsDelta struct {
+	Change  int      `json:"change"`
+	Percent *float64 `json:"percent"` //* null when there was nothing in the previous period
+}
+
+type analyticsFunnelStage struct {
+	Stage string   `json:"stage"`
+	Count int      `json:"count"`
+	Rate  *float64 `json:"rate"` //* fraction of the previous stage which reached this one, null for the first stage
+}
+
+type analyticsOpening struct {
+	ID                   uuid.UUID `json:"id"`
+	Title                string    `json:"title"`
+	Applications         int       `json:"applications"`
+	AcceptedApplications int       `json:"acceptedApplications"`
+}
+
+// getAnalyticsRange reads the range (7d, 30d, 90d or custom with from and to) and the interval (day or week) from the query.
+func getAnalyticsRange(c *fiber.Ctx) (time.Time, time.Time, int, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	var from, to time.Time
+	switch c.Query("range", "30d") {
+	case "7d":
+		from, to = today.AddDate(0, 0, -6), today
+	case "30d":
+		from, to = today.AddDate(0, 0, -29), today
+	case "90d":
+		from, to = today.AddDate(0, 0, -89), today
+	case "custom":
+		var err error
+		from, err = time.Parse(analyticsDateLayout, c.Query("from"))
+		if err != nil {
+			return from, to, 0, &fiber.Error{Code: 400, Message: "Invalid From Date, use YYYY-MM-DD."}
+		}
+		to, err = time.Parse(analyticsDateLayout, c.Query("to"))
+		if err != nil {
+			return from, to, 0, &fiber.Error{Code: 400, Message: "Invalid To Date, use YYYY-MM-DD."}
+		}
+		if to.After(today) {
+			to = today
+		}
+		if from.After(to) {
+			return from, to, 0, &fiber.Error{Code: 400, Message: "From Date cannot be after the To Date."}
+		}
+		if int(to.Sub(from).Hours()/24)+1 > config.MAX_ANALYTICS_RANGE_DAYS {
+			return from, to, 0, &fiber.Error{Code: 400, Message: "Range cannot be longer than a year."}
+		}
+	default:
+		return from, to, 0, &fiber.Error{Code: 400, Message: "Range can only be 7d, 30d, 90d or custom."}
+	}
+
+	days := int(to.Sub(from).Hours()/24) + 1
+
+	interval := c.Query("interval")
+	if interval == "" {
+		interval = "day"
+		if days > 31 {
+			interval = "week"
+		}
+	}
+
+	switch interval {
+	case "day":
+		return from, to, 1, nil
+	case "week":
+		return from, to, 7, nil
+	default:
+		return from, to, 0, &fiber.Error{Code: 400, Message: "Interval can only be day or week."}
+	}
+}
+
+func countUniqueViewers(projectID uuid.UUID, from time.Time, to time.Time) (int, error) {
+	var count int64
+	err := initializers.DB.Model(&models.ProjectViewer{}).
+		Where("project_id = ? AND date BETWEEN ? AND ?", projectID, from.Format(analyticsDateLayout), to.Format(analyticsDateLayout)).
+		Distinct("user_id").
+		Count(&count).Error
+	return int(count), err
+}
+
+func analyticsRate(count int, total int) *float64 {
+	if total == 0 {
+		return nil
+	}
+	rate := float64(count) / float64(total)
+	return &rate
+}
+
+func analyticsDeltas(current models.ProjectAnalyticsMetrics, previous models.ProjectAnalyticsMetrics) map[string]analyticsDelta {
+	delta := func(now int, before int) analyticsDelta {
+		change := now - before
+		return analyticsDelta{Change: change, Percent: analyticsRate(change, before)}
+	}
+
+	return map[string]analyticsDelta{
+		"views":                delta(current.Views, previous.Views),
+		"uniqueViewers":        delta(current.UniqueViewers, previous.UniqueViewers),
+		"impressions":          delta(current.Impressions, previous.Impressions),
+		"likes":                delta(current.Likes, previous.Likes),
+		"comments":             delta(current.Comments, previous.Comments),
+		"shares":               delta(current.Shares, previous.Shares),
+		"bookmarks":            delta(current.Bookmarks, previous.Bookmarks),
+		"applications":         delta(current.Applications, previous.Applications),
+		"acceptedApplications": delta(current.AcceptedApplications, previous.AcceptedApplications),
+	}
+}
+
+// GetProjectAnalytics gets the analytics of the project over the range, in daily or weekly buckets,
+// with the change from the period of the same length just before it.
+func GetProjectAnalytics(c *fiber.Ctx) error {
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Project ID"}
+	}
+
+	from, to, bucketDays, err := getAnalyticsRange(c)
+	if err != nil {
+		return err
+	}
+
+	days := int(to.Sub(from).Hours()/24) + 1
+	previousFrom, previousTo := from.AddDate(0, 0, -days), from.AddDate(0, 0, -1)
+
+	var stats []models.ProjectDailyStat
+	if err := initializers.DB.
+		Where("project_id = ? AND date BETWEEN ? AND ?", parsedProjectID, previousFrom.Format(analyticsDateLayout), to.Format(analyticsDateLayout)).
+		Find(&stats).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	series := make([]analyticsBucket, (days+bucketDays-1)/bucketDays)
+	for i := range series {
+		series[i].Date = from.AddDate(0, 0, i*bucketDays)
+	}
+
+	var totals, previousTotals models.ProjectAnalyticsMetrics
+	for _, stat := range stats {
+		date := stat.Date.UTC().Truncate(24 * time.Hour)
+		if date.Before(from) {
+			previousTotals.Add(stat.ProjectAnalyticsMetrics)
+			continue
+		}
+		series[int(date.Sub(from).Hours()/24)/bucketDays].Add(stat.ProjectAnalyticsMetrics)
+		totals.Add(stat.ProjectAnalyticsMetrics)
+	}
+
+	var viewerCounts []struct {
+		Bucket int
+		Count  int
+	}
+	if err := initializers.DB.Model(&models.ProjectViewer{}).
+		Select("(date - ?::date) / ? AS bucket, COUNT(DISTINCT user_id) AS count", from.Format(analyticsDateLayout), bucketDays).
+		Where("project_id = ? AND date BETWEEN ? AND ?", parsedProjectID, from.Format(analyticsDateLayout), to.Format(analyticsDateLayout)).
+		Group("bucket").
+		Scan(&viewerCounts).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	for _, viewerCount := range viewerCounts {
+		if viewerCount.Bucket >= 0 && viewerCount.Bucket < len(series) {
+			series[viewerCount.Bucket].UniqueViewers = viewerCount.Count
+		}
+	}
+
+	if totals.UniqueViewers, err = countUniqueViewers(parsedProjectID, from, to); err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	if previousTotals.UniqueViewers, err = countUniqueViewers(parsedProjectID, previousFrom, previousTo); err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	funnel := []analyticsFunnelStage{
+		{Stage: "impressions", Count: totals.Impressions},
+		{Stage: "views", Count: totals.Views},
+		{Stage: "applications", Count: totals.Applications},
+		{Stage: "acceptedApplications", Count: totals.AcceptedApplications},
+	}
+	for i := 1; i < len(funnel); i++ {
+		funnel[i].Rate = analyticsRate(funnel[i].Count, funnel[i-1].Count)
+	}
+
+	var openings []analyticsOpening
+	if err := initializers.DB.Model(&models.Opening{}).
+		Select("openings.id, openings.title, COUNT(applications.id) AS applications, COUNT(applications.id) FILTER (WHERE applications.status = 2) AS accepted_applications").
+		Joins("LEFT JOIN applications ON applications.opening_id = openings.id AND applications.created_at >= ? AND applications.created_at < ?", from, to.AddDate(0, 0, 1)).
+		Where("openings.project_id = ?", parsedProjectID).
+		Group("openings.id, openings.title").
+		Order("applications DESC").
+		Scan(&openings).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	interval := "day"
+	if bucketDays == 7 {
+		interval = "week"
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status": "success",
+		"range": fiber.Map{
+			"from":     from.Format(analyticsDateLayout),
+			"to":       to.Format(analyticsDateLayout),
+			"interval": interval,
+		},
+		"totals":         totals,
+		"previousTotals": previousTotals,
+		"deltas":         analyticsDeltas(totals, previousTotals),
+		"series":         series,
+		"funnel":         funnel,
+		"openings":       openings,
+	})
+}
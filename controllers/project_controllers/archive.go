@@ -0,0 +1,75 @@
+package project_controllers
+
+import (
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// setProjectArchived archives or unarchives a project of the logged in user, archived projects stay viewable but are read-only.
+func setProjectArchived(c *fiber.Ctx, archived bool) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var project models.Project
+	if err := initializers.DB.First(&project, "id = ? AND user_id = ?", parsedProjectID, loggedInUserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Project of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if project.IsArchived == archived {
+		if archived {
+			return &fiber.Error{Code: 400, Message: "Project is already archived."}
+		}
+		return &fiber.Error{Code: 400, Message: "Project is not archived."}
+	}
+
+	project.IsArchived = archived
+	project.ArchivedAt = nil
+	if archived {
+		now := time.Now()
+		project.ArchivedAt = &now
+	}
+
+	if err := initializers.DB.Model(&project).Select("is_archived", "archived_at").Updates(&project).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	historyType := 14
+	if archived {
+		historyType = 13
+	}
+
+	go routines.MarkProjectHistory(project.ID, getActualUserID(c), historyType, nil, nil, nil, nil, nil, "")
+	go cache.RemoveProject(project.Slug)
+	go cache.RemoveProject("-workspace--" + project.Slug)
+	go cache.RemoveProject("-access--" + project.ID.String())
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "",
+		"project": project,
+	})
+}
+
+func ArchiveProject(c *fiber.Ctx) error {
+	return setProjectArchived(c, true)
+}
+
+func UnarchiveProject(c *fiber.Ctx) error {
+	return setProjectArchived(c, false)
+}
@@ -0,0 +1,627 @@
+package project_controllers
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/schemas"
+	"github.com/Pratham-Mishra04/interact/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func getProjectFileFromParams(c *fiber.Ctx) (*models.ProjectFile, error) {
+	parsedFileID, err := uuid.Parse(c.Params("fileID"))
+	if err != nil {
+		return nil, &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var file models.ProjectFile
+	if err := initializers.DB.First(&file, "id = ?", parsedFileID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, &fiber.Error{Code: 400, Message: "No File of this ID found."}
+		}
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return &file, nil
+}
+
+func getProjectFolderFromParams(c *fiber.Ctx) (*models.ProjectFolder, error) {
+	parsedFolderID, err := uuid.Parse(c.Params("folderID"))
+	if err != nil {
+		return nil, &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var folder models.ProjectFolder
+	if err := initializers.DB.First(&folder, "id = ?", parsedFolderID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, &fiber.Error{Code: 400, Message: "No Folder of this ID found."}
+		}
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return &folder, nil
+}
+
+// getProjectFolderID checks that the folder belongs to the project, an empty folder ID is the root of the project.
+func getProjectFolderID(projectID uuid.UUID, folderID string) (*uuid.UUID, error) {
+	if folderID == "" {
+		return nil, nil
+	}
+
+	parsedFolderID, err := uuid.Parse(folderID)
+	if err != nil {
+		return nil, &fiber.Error{Code: 400, Message: "Invalid Folder ID."}
+	}
+
+	var folder models.ProjectFolder
+	if err := initializers.DB.First(&folder, "id = ? AND project_id = ?", parsedFolderID, projectID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, &fiber.Error{Code: 400, Message: "No Folder of this ID found."}
+		}
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return &folder.ID, nil
+}
+
+// getFolderTreeIDs gets the IDs of the folder and of all the folders nested in it.
+func getFolderTreeIDs(db *gorm.DB, folderID uuid.UUID) ([]uuid.UUID, error) {
+	var folderIDs []uuid.UUID
+	if err := db.Raw(`WITH RECURSIVE tree AS (
+		SELECT id FROM project_folders WHERE id = ?
+		UNION ALL
+		SELECT project_folders.id FROM project_folders JOIN tree ON project_folders.parent_id = tree.id
+	) SELECT id FROM tree`, folderID).Scan(&folderIDs).Error; err != nil {
+		return nil, err
+	}
+	return folderIDs, nil
+}
+
+// freeProjectStorage gives the size of deleted file versions back to the storage quota of the project.
+func freeProjectStorage(db *gorm.DB, projectID uuid.UUID, size int64) error {
+	return db.Model(&models.Project{}).Where("id = ?", projectID).Update("storage_used", gorm.Expr("GREATEST(storage_used - ?, 0)", size)).Error
+}
+
+func GetProjectFiles(c *fiber.Ctx) error {
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	folderID, err := getProjectFolderID(parsedProjectID, c.Query("folderID"))
+	if err != nil {
+		return err
+	}
+
+	folderDB := initializers.DB.Preload("CreatedBy").Where("project_id = ?", parsedProjectID)
+	fileDB := initializers.DB.Preload("UploadedBy").Where("project_id = ?", parsedProjectID)
+	if folderID != nil {
+		folderDB = folderDB.Where("parent_id = ?", folderID)
+		fileDB = fileDB.Where("folder_id = ?", folderID)
+	} else {
+		folderDB = folderDB.Where("parent_id IS NULL")
+		fileDB = fileDB.Where("folder_id IS NULL")
+	}
+
+	var folders []models.ProjectFolder
+	if err := folderDB.Order("name ASC").Find(&folders).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	var files []models.ProjectFile
+	if err := fileDB.Order("name ASC").Find(&files).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	var project models.Project
+	if err := initializers.DB.Select("id", "storage_used").First(&project, "id = ?", parsedProjectID).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":       "success",
+		"folders":      folders,
+		"files":        files,
+		"storageUsed":  project.StorageUsed,
+		"storageQuota": config.PROJECT_STORAGE_QUOTA,
+	})
+}
+
+func GetProjectFile(c *fiber.Ctx) error {
+	parsedFileID, err := uuid.Parse(c.Params("fileID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var file models.ProjectFile
+	if err := initializers.DB.
+		Preload("UploadedBy").
+		Preload("Versions", func(db *gorm.DB) *gorm.DB {
+			return db.Order("version DESC")
+		}).
+		Preload("Versions.UploadedBy").
+		First(&file, "id = ?", parsedFileID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No File of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status": "success",
+		"file":   file,
+	})
+}
+
+// DownloadProjectFile gives a short lived link to the latest version of the file, or to the version in the query.
+func DownloadProjectFile(c *fiber.Ctx) error {
+	file, err := getProjectFileFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	version := c.QueryInt("version", file.LatestVersion)
+
+	var fileVersion models.ProjectFileVersion
+	if err := initializers.DB.First(&fileVersion, "file_id = ? AND version = ?", file.ID, version).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Version of this File found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	url, err := helpers.ProjectFileClient.SignedURL(fileVersion.Path, config.PROJECT_FILE_URL_TTL)
+	if err != nil {
+		return helpers.AppError{Code: 500, Message: config.SERVER_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"url":     url,
+		"name":    file.Name,
+		"version": fileVersion.Version,
+	})
+}
+
+// UploadProjectFile adds a file to the project, uploading a file with the name of an existing one in the same folder adds a new version of it.
+func UploadProjectFile(c *fiber.Ctx) error {
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	memberID := getActualUserID(c)
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "No File provided."}
+	}
+
+	if fileHeader.Size > config.MAX_PROJECT_FILE_SIZE {
+		return &fiber.Error{Code: 400, Message: fmt.Sprintf("Files can be of at most %dMB.", config.MAX_PROJECT_FILE_SIZE/(1024*1024))}
+	}
+
+	name := strings.TrimSpace(c.FormValue("name", fileHeader.Filename))
+	if name == "" || len(name) > 100 {
+		return &fiber.Error{Code: 400, Message: "File name must be between 1 and 100 characters."}
+	}
+
+	folderID, err := getProjectFolderID(parsedProjectID, c.FormValue("folderID"))
+	if err != nil {
+		return err
+	}
+
+	var project models.Project
+	if err := initializers.DB.Select("id", "storage_used").First(&project, "id = ?", parsedProjectID).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if project.StorageUsed+fileHeader.Size > config.PROJECT_STORAGE_QUOTA {
+		return &fiber.Error{Code: 400, Message: "This project has run out of storage, delete some files to upload more."}
+	}
+
+	path, err := utils.UploadProjectFile(c, fileHeader, parsedProjectID.String())
+	if err != nil {
+		return helpers.AppError{Code: 500, Message: config.SERVER_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	mimeType := fileHeader.Header.Get("Content-Type")
+
+	var file models.ProjectFile
+	var prunedPaths []string
+	historyType := 17
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		//* the project row is locked so that parallel uploads can not go over the quota together
+		var lockedProject models.Project
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "storage_used").First(&lockedProject, "id = ?", parsedProjectID).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if lockedProject.StorageUsed+fileHeader.Size > config.PROJECT_STORAGE_QUOTA {
+			return &fiber.Error{Code: 400, Message: "This project has run out of storage, delete some files to upload more."}
+		}
+
+		fileDB := tx.Where("project_id = ? AND name = ?", parsedProjectID, name)
+		if folderID != nil {
+			fileDB = fileDB.Where("folder_id = ?", folderID)
+		} else {
+			fileDB = fileDB.Where("folder_id IS NULL")
+		}
+
+		err := fileDB.First(&file).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if err == gorm.ErrRecordNotFound {
+			file = models.ProjectFile{
+				ProjectID:     parsedProjectID,
+				FolderID:      folderID,
+				Name:          name,
+				MimeType:      mimeType,
+				Size:          fileHeader.Size,
+				LatestVersion: 1,
+				UploadedByID:  memberID,
+			}
+			if err := tx.Create(&file).Error; err != nil {
+				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+			}
+		} else {
+			historyType = 18
+			file.MimeType = mimeType
+			file.Size = fileHeader.Size
+			file.LatestVersion++
+			file.UploadedByID = memberID
+			file.UpdatedAt = time.Now()
+			if err := tx.Select("mime_type", "size", "latest_version", "uploaded_by_id", "updated_at").Updates(&file).Error; err != nil {
+				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+			}
+		}
+
+		fileVersion := models.ProjectFileVersion{
+			FileID:       file.ID,
+			Version:      file.LatestVersion,
+			Path:         path,
+			Size:         fileHeader.Size,
+			MimeType:     mimeType,
+			UploadedByID: memberID,
+		}
+		if err := tx.Create(&fileVersion).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		storageUsed := fileHeader.Size
+
+		var prunedVersions []models.ProjectFileVersion
+		if err := tx.Where("file_id = ?", file.ID).Order("version DESC").Offset(config.MAX_PROJECT_FILE_VERSIONS).Find(&prunedVersions).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if len(prunedVersions) > 0 {
+			if err := tx.Delete(&prunedVersions).Error; err != nil {
+				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+			}
+			for _, version := range prunedVersions {
+				prunedPaths = append(prunedPaths, version.Path)
+				storageUsed -= version.Size
+			}
+		}
+
+		if err := tx.Model(&lockedProject).Update("storage_used", gorm.Expr("storage_used + ?", storageUsed)).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		return nil
+	}); err != nil {
+		go routines.DeleteFromBucket(helpers.ProjectFileClient, path)
+		return err
+	}
+
+	go routines.DeleteProjectFiles(prunedPaths)
+	go routines.MarkProjectHistory(parsedProjectID, memberID, historyType, nil, nil, nil, nil, nil, file.Name)
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":  "success",
+		"message": "File uploaded",
+		"file":    file,
+	})
+}
+
+func UpdateProjectFile(c *fiber.Ctx) error {
+	var reqBody schemas.ProjectFileUpdateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.ProjectFileUpdateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	file, err := getProjectFileFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	if name := strings.TrimSpace(reqBody.Name); name != "" {
+		file.Name = name
+	}
+	if reqBody.FolderID != nil {
+		folderID, err := getProjectFolderID(file.ProjectID, *reqBody.FolderID)
+		if err != nil {
+			return err
+		}
+		file.FolderID = folderID
+	}
+
+	conflictDB := initializers.DB.Model(&models.ProjectFile{}).Where("project_id = ? AND name = ? AND id <> ?", file.ProjectID, file.Name, file.ID)
+	if file.FolderID != nil {
+		conflictDB = conflictDB.Where("folder_id = ?", file.FolderID)
+	} else {
+		conflictDB = conflictDB.Where("folder_id IS NULL")
+	}
+
+	var count int64
+	if err := conflictDB.Count(&count).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	if count > 0 {
+		return &fiber.Error{Code: 400, Message: "A File of this name already exists in this folder."}
+	}
+
+	file.UpdatedAt = time.Now()
+	if err := initializers.DB.Model(file).Select("name", "folder_id", "updated_at").Updates(file).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "File updated",
+		"file":    file,
+	})
+}
+
+func DeleteProjectFile(c *fiber.Ctx) error {
+	file, err := getProjectFileFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		//* the project row is locked first, like uploads do, then the file row, so that no version is uploaded while it is deleted
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.Project{}, "id = ?", file.ProjectID).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id").First(&models.ProjectFile{}, "id = ?", file.ID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return &fiber.Error{Code: 400, Message: "No File of this ID found."}
+			}
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		var versions []models.ProjectFileVersion
+		if err := tx.Where("file_id = ?", file.ID).Find(&versions).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		var size int64
+		for _, version := range versions {
+			paths = append(paths, version.Path)
+			size += version.Size
+		}
+
+		if err := tx.Delete(file).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		if err := freeProjectStorage(tx, file.ProjectID, size); err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	go routines.DeleteProjectFiles(paths)
+	go routines.MarkProjectHistory(file.ProjectID, getActualUserID(c), 19, nil, nil, nil, nil, nil, file.Name)
+
+	return c.Status(204).JSON(fiber.Map{
+		"status":  "success",
+		"message": "File deleted",
+	})
+}
+
+// DeleteProjectFileVersion deletes an older version of the file, the only version left can be removed by deleting the file.
+func DeleteProjectFileVersion(c *fiber.Ctx) error {
+	file, err := getProjectFileFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	version, err := c.ParamsInt("version")
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Version."}
+	}
+
+	var fileVersion models.ProjectFileVersion
+	if err := initializers.DB.First(&fileVersion, "file_id = ? AND version = ?", file.ID, version).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Version of this File found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if fileVersion.Version == file.LatestVersion {
+		return &fiber.Error{Code: 400, Message: "Cannot delete the latest version, upload a new one or delete the file."}
+	}
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(&fileVersion).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		if err := freeProjectStorage(tx, file.ProjectID, fileVersion.Size); err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	go routines.DeleteFromBucket(helpers.ProjectFileClient, fileVersion.Path)
+
+	return c.Status(204).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Version deleted",
+	})
+}
+
+func AddProjectFolder(c *fiber.Ctx) error {
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var reqBody schemas.ProjectFolderCreateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.ProjectFolderCreateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	parentID, err := getProjectFolderID(parsedProjectID, reqBody.ParentID)
+	if err != nil {
+		return err
+	}
+
+	memberID := getActualUserID(c)
+
+	folder := models.ProjectFolder{
+		ProjectID:   parsedProjectID,
+		ParentID:    parentID,
+		Name:        strings.TrimSpace(reqBody.Name),
+		CreatedByID: memberID,
+	}
+
+	if err := initializers.DB.Create(&folder).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go routines.MarkProjectHistory(parsedProjectID, memberID, 20, nil, nil, nil, nil, nil, folder.Name)
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Folder created",
+		"folder":  folder,
+	})
+}
+
+func UpdateProjectFolder(c *fiber.Ctx) error {
+	var reqBody schemas.ProjectFolderUpdateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.ProjectFolderUpdateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	folder, err := getProjectFolderFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	if name := strings.TrimSpace(reqBody.Name); name != "" {
+		folder.Name = name
+	}
+	if reqBody.ParentID != nil {
+		parentID, err := getProjectFolderID(folder.ProjectID, *reqBody.ParentID)
+		if err != nil {
+			return err
+		}
+
+		if parentID != nil {
+			folderIDs, err := getFolderTreeIDs(initializers.DB, folder.ID)
+			if err != nil {
+				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+			}
+			for _, folderID := range folderIDs {
+				if folderID == *parentID {
+					return &fiber.Error{Code: 400, Message: "Cannot move a Folder into itself."}
+				}
+			}
+		}
+
+		folder.ParentID = parentID
+	}
+
+	if err := initializers.DB.Model(folder).Select("name", "parent_id").Updates(folder).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Folder updated",
+		"folder":  folder,
+	})
+}
+
+// DeleteProjectFolder deletes the folder along with all the folders and files nested in it.
+func DeleteProjectFolder(c *fiber.Ctx) error {
+	folder, err := getProjectFolderFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	folderIDs, err := getFolderTreeIDs(initializers.DB, folder.ID)
+	if err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	var versions []models.ProjectFileVersion
+	if err := initializers.DB.
+		Joins("JOIN project_files ON project_files.id = project_file_versions.file_id").
+		Where("project_files.folder_id IN ?", folderIDs).
+		Find(&versions).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	var paths []string
+	var size int64
+	for _, version := range versions {
+		paths = append(paths, version.Path)
+		size += version.Size
+	}
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Delete(folder).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		if err := freeProjectStorage(tx, folder.ProjectID, size); err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	go routines.DeleteProjectFiles(paths)
+	go routines.MarkProjectHistory(folder.ProjectID, getActualUserID(c), 21, nil, nil, nil, nil, nil, folder.Name)
+
+	return c.Status(204).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Folder deleted",
+	})
+}
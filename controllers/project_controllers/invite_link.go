@@ -0,0 +1,262 @@
+package project_controllers
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/schemas"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func generateInviteCode() (string, error) {
+	b := make([]byte, config.INVITE_LINK_CODE_LENGTH)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// checkInviteLinkUsable reports why the link cannot be used anymore, if it cannot.
+func checkInviteLinkUsable(link *models.ProjectInviteLink) error {
+	if time.Now().After(link.ExpiresAt) {
+		return &fiber.Error{Code: 400, Message: "This invite link has expired."}
+	}
+	if link.MaxUses > 0 && link.NoUses >= link.MaxUses {
+		return &fiber.Error{Code: 400, Message: "This invite link has been used the maximum number of times."}
+	}
+	return nil
+}
+
+func GetProjectInviteLinks(c *fiber.Ctx) error {
+	var links []models.ProjectInviteLink
+	if err := initializers.DB.
+		Preload("CreatedBy").
+		Where("project_id = ?", c.Params("projectID")).
+		Order("created_at DESC").
+		Find(&links).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":      "success",
+		"inviteLinks": links,
+	})
+}
+
+// AddProjectInviteLink creates a link which adds whoever opens it to the project with the given role and title.
+func AddProjectInviteLink(c *fiber.Ctx) error {
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Project ID"}
+	}
+
+	var reqBody schemas.ProjectInviteLinkCreateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.ProjectInviteLinkCreateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	var project models.Project
+	if err := initializers.DB.Preload("Roles").First(&project, "id = ?", parsedProjectID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Project of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	roleFound := false
+	for _, role := range project.Roles {
+		if role.Name == reqBody.Role {
+			roleFound = true
+			break
+		}
+	}
+	if _, isDefault := models.DefaultProjectRoles[reqBody.Role]; !roleFound && !isDefault {
+		return &fiber.Error{Code: 400, Message: "No Role of this name found."}
+	}
+
+	memberID := getActualUserID(c)
+
+	//* only the owner can hand out links which give the permission to manage members, for org projects that is the owner of the org
+	if memberID != project.UserID && models.HasPermission(project.Roles, reqBody.Role, models.ProjectMembersPermission) {
+		return &fiber.Error{Code: 403, Message: "Cannot perform this action."}
+	}
+
+	code, err := generateInviteCode()
+	if err != nil {
+		return helpers.AppError{Code: 500, Message: config.SERVER_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	ttl := config.INVITE_LINK_DEFAULT_TTL
+	if reqBody.ExpiresInDays > 0 {
+		ttl = time.Duration(reqBody.ExpiresInDays) * 24 * time.Hour
+	}
+
+	link := models.ProjectInviteLink{
+		ProjectID:   parsedProjectID,
+		Code:        code,
+		Role:        reqBody.Role,
+		Title:       reqBody.Title,
+		MaxUses:     reqBody.MaxUses,
+		ExpiresAt:   time.Now().Add(ttl),
+		CreatedByID: memberID,
+	}
+
+	if err := initializers.DB.Create(&link).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go routines.MarkProjectHistory(parsedProjectID, memberID, 26, nil, nil, nil, nil, nil, link.Title)
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":     "success",
+		"message":    "Invite link created",
+		"inviteLink": link,
+	})
+}
+
+func DeleteProjectInviteLink(c *fiber.Ctx) error {
+	var link models.ProjectInviteLink
+	if err := initializers.DB.First(&link, "id = ?", c.Params("inviteLinkID")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Invite Link of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := initializers.DB.Delete(&link).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(204).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Invite link deleted",
+	})
+}
+
+// GetInviteLink shows the project of the link to the user before they join.
+func GetInviteLink(c *fiber.Ctx) error {
+	var link models.ProjectInviteLink
+	if err := initializers.DB.Preload("Project").Preload("CreatedBy").First(&link, "code = ?", c.Params("code")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "Invalid invite link."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := checkInviteLinkUsable(&link); err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":     "success",
+		"inviteLink": link,
+	})
+}
+
+// JoinProjectWithInviteLink adds the logged in user to the project of the link, with the role and title of the link.
+func JoinProjectWithInviteLink(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+	parsedLoggedInUserID, _ := uuid.Parse(loggedInUserID)
+
+	var user models.User
+	if err := initializers.DB.First(&user, "id = ?", loggedInUserID).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	if !user.Verified {
+		return &fiber.Error{Code: 401, Message: config.VERIFICATION_ERROR}
+	}
+
+	var link models.ProjectInviteLink
+	var project models.Project
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		//* the link row is locked so that parallel joins can not go over the maximum uses together
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&link, "code = ?", c.Params("code")).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return &fiber.Error{Code: 400, Message: "Invalid invite link."}
+			}
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if err := checkInviteLinkUsable(&link); err != nil {
+			return err
+		}
+
+		if err := tx.First(&project, "id = ?", link.ProjectID).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if project.IsArchived {
+			return &fiber.Error{Code: 403, Message: "This project is archived."}
+		}
+
+		if routines.IsBlocked(parsedLoggedInUserID, project.UserID) || routines.IsBlocked(parsedLoggedInUserID, link.CreatedByID) {
+			return &fiber.Error{Code: 403, Message: "You cannot join this project."}
+		}
+
+		isCollaborator, err := isProjectCollaborator(tx, &project, parsedLoggedInUserID)
+		if err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		if isCollaborator {
+			return &fiber.Error{Code: 400, Message: "You are already a collaborator of this project."}
+		}
+
+		membership := models.Membership{
+			UserID:    parsedLoggedInUserID,
+			ProjectID: project.ID,
+			Title:     link.Title,
+			Role:      link.Role,
+		}
+		if err := tx.Create(&membership).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if err := tx.Model(&link).UpdateColumn("no_uses", gorm.Expr("no_uses + 1")).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		//* the user is in now, so their pending request and invitations to the project are settled
+		if err := tx.Model(&models.ProjectJoinRequest{}).
+			Where("project_id = ? AND user_id = ? AND status = 0", project.ID, parsedLoggedInUserID).
+			Update("status", 1).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		if err := tx.Model(&models.Invitation{}).
+			Where("project_id = ? AND user_id = ? AND status = 0", project.ID, parsedLoggedInUserID).
+			Update("status", 1).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	go routines.IncrementProjectMember(project.ID)
+	go routines.MarkProjectHistory(project.ID, parsedLoggedInUserID, 27, nil, nil, nil, nil, nil, "")
+	go routines.SendInviteLinkJoinedNotification(project.UserID, parsedLoggedInUserID, project.ID)
+	go cache.RemoveProject(project.Slug)
+	go cache.RemoveProject("-workspace--" + project.Slug)
+	go cache.RemoveProject("-access--" + project.ID.String())
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Joined the project",
+		"project": project,
+	})
+}
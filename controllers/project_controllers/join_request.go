@@ -0,0 +1,287 @@
+package project_controllers
+
+import (
+	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/schemas"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// isProjectCollaborator reports whether the user owns or is a member of the project.
+func isProjectCollaborator(db *gorm.DB, project *models.Project, userID uuid.UUID) (bool, error) {
+	if project.UserID == userID {
+		return true, nil
+	}
+
+	var count int64
+	if err := db.Model(&models.Membership{}).Where("project_id = ? AND user_id = ?", project.ID, userID).Count(&count).Error; err != nil {
+		return false, err
+	}
+	return count > 0, nil
+}
+
+// RequestToJoinProject lets a user ask to join a public project, the managers of the project then accept or reject it.
+func RequestToJoinProject(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+	parsedLoggedInUserID, _ := uuid.Parse(loggedInUserID)
+
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Project ID"}
+	}
+
+	var reqBody schemas.ProjectJoinRequestCreateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.ProjectJoinRequestCreateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	var user models.User
+	if err := initializers.DB.First(&user, "id = ?", loggedInUserID).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	if !user.Verified {
+		return &fiber.Error{Code: 401, Message: config.VERIFICATION_ERROR}
+	}
+
+	var project models.Project
+	if err := initializers.DB.First(&project, "id = ?", parsedProjectID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Project of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if project.IsPrivate {
+		return &fiber.Error{Code: 403, Message: "This project is private."}
+	}
+
+	isCollaborator, err := isProjectCollaborator(initializers.DB, &project, parsedLoggedInUserID)
+	if err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	if isCollaborator {
+		return &fiber.Error{Code: 400, Message: "You are already a collaborator of this project."}
+	}
+
+	if routines.IsBlocked(parsedLoggedInUserID, project.UserID) {
+		return &fiber.Error{Code: 403, Message: "You cannot request to join this project."}
+	}
+
+	var existingRequest models.ProjectJoinRequest
+	if err := initializers.DB.First(&existingRequest, "project_id = ? AND user_id = ? AND status = 0", parsedProjectID, parsedLoggedInUserID).Error; err == nil {
+		return &fiber.Error{Code: 400, Message: "You have already requested to join this project."}
+	}
+
+	var existingInvitation models.Invitation
+	if err := initializers.DB.First(&existingInvitation, "project_id = ? AND user_id = ? AND status = 0", parsedProjectID, parsedLoggedInUserID).Error; err == nil {
+		return &fiber.Error{Code: 400, Message: "You have already been invited to this project, accept the invitation to join."}
+	}
+
+	joinRequest := models.ProjectJoinRequest{
+		ProjectID: parsedProjectID,
+		UserID:    parsedLoggedInUserID,
+		Message:   reqBody.Message,
+	}
+
+	if err := initializers.DB.Create(&joinRequest).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go routines.SendJoinRequestNotification(parsedLoggedInUserID, parsedProjectID)
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":      "success",
+		"message":     "Request sent to the project.",
+		"joinRequest": joinRequest,
+	})
+}
+
+func GetMyJoinRequests(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	var joinRequests []models.ProjectJoinRequest
+	if err := initializers.DB.
+		Preload("Project").
+		Where("user_id = ?", loggedInUserID).
+		Order("created_at DESC").
+		Find(&joinRequests).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":       "success",
+		"joinRequests": joinRequests,
+	})
+}
+
+func WithdrawJoinRequest(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	var joinRequest models.ProjectJoinRequest
+	if err := initializers.DB.First(&joinRequest, "id = ? AND user_id = ?", c.Params("joinRequestID"), loggedInUserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Request of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if joinRequest.Status != 0 {
+		return &fiber.Error{Code: 400, Message: "Cannot Perform this action."}
+	}
+
+	if err := initializers.DB.Delete(&joinRequest).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(204).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Request withdrawn",
+	})
+}
+
+// GetProjectJoinRequests gets the requests of the project which are waiting for a decision.
+func GetProjectJoinRequests(c *fiber.Ctx) error {
+	var joinRequests []models.ProjectJoinRequest
+	if err := initializers.DB.
+		Preload("User").
+		Where("project_id = ? AND status = 0", c.Params("projectID")).
+		Order("created_at DESC").
+		Find(&joinRequests).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":       "success",
+		"joinRequests": joinRequests,
+	})
+}
+
+// AcceptJoinRequest adds the user who sent the request to the project as a Member.
+func AcceptJoinRequest(c *fiber.Ctx) error {
+	parsedJoinRequestID, err := uuid.Parse(c.Params("joinRequestID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var reqBody struct {
+		Title string `json:"title"`
+	}
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if reqBody.Title == "" {
+		reqBody.Title = string(models.ProjectMember)
+	}
+	if len(reqBody.Title) > 25 {
+		return &fiber.Error{Code: 400, Message: "Title cannot be longer than 25 characters."}
+	}
+
+	memberID := getActualUserID(c)
+
+	var joinRequest models.ProjectJoinRequest
+	var project models.Project
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		//* locked so that the same request is not accepted twice
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&joinRequest, "id = ?", parsedJoinRequestID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return &fiber.Error{Code: 400, Message: "No Request of this ID found."}
+			}
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if joinRequest.Status != 0 {
+			return &fiber.Error{Code: 400, Message: "Cannot Perform this action."}
+		}
+
+		if err := tx.First(&project, "id = ?", joinRequest.ProjectID).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if routines.IsBlocked(joinRequest.UserID, project.UserID) {
+			return &fiber.Error{Code: 403, Message: "Cannot add this user to the project."}
+		}
+
+		isCollaborator, err := isProjectCollaborator(tx, &project, joinRequest.UserID)
+		if err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		if isCollaborator {
+			return &fiber.Error{Code: 400, Message: "User is a already a collaborator of this project."}
+		}
+
+		membership := models.Membership{
+			UserID:    joinRequest.UserID,
+			ProjectID: project.ID,
+			Title:     reqBody.Title,
+			Role:      models.ProjectMember,
+		}
+		if err := tx.Create(&membership).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		joinRequest.Status = 1
+		joinRequest.DecidedByID = &memberID
+		if err := tx.Save(&joinRequest).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	go routines.IncrementProjectMember(project.ID)
+	go routines.MarkProjectHistory(project.ID, memberID, 24, &joinRequest.UserID, nil, nil, nil, nil, "")
+	go routines.SendJoinRequestAcceptedNotification(joinRequest.UserID, memberID, project.ID)
+	go cache.RemoveProject(project.Slug)
+	go cache.RemoveProject("-workspace--" + project.Slug)
+	go cache.RemoveProject("-access--" + project.ID.String())
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Request Accepted",
+	})
+}
+
+func RejectJoinRequest(c *fiber.Ctx) error {
+	var joinRequest models.ProjectJoinRequest
+	if err := initializers.DB.First(&joinRequest, "id = ?", c.Params("joinRequestID")).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Request of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if joinRequest.Status != 0 {
+		return &fiber.Error{Code: 400, Message: "Cannot Perform this action."}
+	}
+
+	memberID := getActualUserID(c)
+
+	joinRequest.Status = -1
+	joinRequest.DecidedByID = &memberID
+
+	if err := initializers.DB.Save(&joinRequest).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go routines.MarkProjectHistory(joinRequest.ProjectID, memberID, 25, &joinRequest.UserID, nil, nil, nil, nil, "")
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Request Rejected",
+	})
+}
@@ -219,13 +219,31 @@ func ChangeMemberRole(c *fiber.Ctx) error {
 	}
 
 	var membership models.Membership
-	if err := initializers.DB.Preload("Project").First(&membership, "id = ?", parsedMembershipID).Error; err != nil {
+	if err := initializers.DB.Preload("Project").Preload("Project.Roles").First(&membership, "id = ?", parsedMembershipID).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return &fiber.Error{Code: 400, Message: "No Membership of this ID found."}
 		}
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
 
+	if reqBody.Role == "" {
+		reqBody.Role = membership.Role
+	}
+
+	roles := membership.Project.Roles
+
+	roleFound := false
+	for _, role := range roles {
+		if role.Name == reqBody.Role {
+			roleFound = true
+			break
+		}
+	}
+	if _, isDefault := models.DefaultProjectRoles[reqBody.Role]; !roleFound && !isDefault {
+		return &fiber.Error{Code: 400, Message: "No Role of this name found."}
+	}
+
+	//* members who can manage members cannot change the roles of each other, nor give that permission
 	if parsedLoggedInUserID != membership.Project.UserID {
 		var updatingUserMembership models.Membership
 		if err := initializers.DB.Preload("Project").First(&updatingUserMembership, "project_id = ? AND user_id = ?", membership.ProjectID, parsedLoggedInUserID).Error; err != nil {
@@ -235,13 +253,13 @@ func ChangeMemberRole(c *fiber.Ctx) error {
 			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 		}
 
-		if updatingUserMembership.Role != models.ProjectManager {
+		if !models.HasPermission(roles, updatingUserMembership.Role, models.ProjectMembersPermission) {
 			return &fiber.Error{Code: 403, Message: "Cannot perform this action."}
 		}
-		if membership.Role == models.ProjectManager {
+		if models.HasPermission(roles, membership.Role, models.ProjectMembersPermission) {
 			return &fiber.Error{Code: 403, Message: "Cannot perform this action."}
 		}
-		if reqBody.Role == models.ProjectManager {
+		if models.HasPermission(roles, reqBody.Role, models.ProjectMembersPermission) {
 			return &fiber.Error{Code: 403, Message: "Cannot perform this action."}
 		}
 	}
@@ -0,0 +1,286 @@
+package project_controllers
+
+import (
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/schemas"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// populateMilestoneProgress sets the number of linked and completed tasks, and the progress, of the milestones.
+func populateMilestoneProgress(milestones []models.Milestone) error {
+	if len(milestones) == 0 {
+		return nil
+	}
+
+	milestoneIDs := make([]uuid.UUID, len(milestones))
+	for i, milestone := range milestones {
+		milestoneIDs[i] = milestone.ID
+	}
+
+	var counts []struct {
+		MilestoneID      uuid.UUID
+		NoTasks          int
+		NoCompletedTasks int
+	}
+	if err := initializers.DB.Model(&models.Task{}).
+		Select("milestone_id, COUNT(*) AS no_tasks, COUNT(*) FILTER (WHERE is_completed) AS no_completed_tasks").
+		Where("milestone_id IN ?", milestoneIDs).
+		Group("milestone_id").
+		Scan(&counts).Error; err != nil {
+		return err
+	}
+
+	for _, count := range counts {
+		for i := range milestones {
+			if milestones[i].ID == count.MilestoneID {
+				milestones[i].NoTasks = count.NoTasks
+				milestones[i].NoCompletedTasks = count.NoCompletedTasks
+				milestones[i].Progress = float64(count.NoCompletedTasks) / float64(count.NoTasks)
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func getMilestoneFromParams(c *fiber.Ctx) (*models.Milestone, error) {
+	parsedMilestoneID, err := uuid.Parse(c.Params("milestoneID"))
+	if err != nil {
+		return nil, &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var milestone models.Milestone
+	if err := initializers.DB.First(&milestone, "id = ?", parsedMilestoneID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, &fiber.Error{Code: 400, Message: "No Milestone of this ID found."}
+		}
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return &milestone, nil
+}
+
+func GetProjectMilestones(c *fiber.Ctx) error {
+	projectID := c.Params("projectID")
+
+	var milestones []models.Milestone
+	if err := initializers.DB.
+		Preload("Tasks").
+		Preload("Tasks.Users").
+		Where("project_id = ?", projectID).
+		Order("due_date ASC").
+		Find(&milestones).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := populateMilestoneProgress(milestones); err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":     "success",
+		"milestones": milestones,
+	})
+}
+
+// GetProjectRoadmap shows the milestones of a public project and their progress.
+func GetProjectRoadmap(c *fiber.Ctx) error {
+	slug := c.Params("slug")
+
+	var project models.Project
+	if err := initializers.DB.First(&project, "slug = ? AND is_private = ?", slug, false).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Project of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	var milestones []models.Milestone
+	if err := initializers.DB.
+		Where("project_id = ?", project.ID).
+		Order("due_date ASC").
+		Find(&milestones).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := populateMilestoneProgress(milestones); err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":     "success",
+		"milestones": milestones,
+	})
+}
+
+func AddMilestone(c *fiber.Ctx) error {
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var reqBody schemas.MilestoneCreateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.MilestoneCreateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	milestone := models.Milestone{
+		ProjectID:   parsedProjectID,
+		Title:       reqBody.Title,
+		Description: reqBody.Description,
+		DueDate:     reqBody.DueDate,
+		Status:      models.MilestonePlanned,
+	}
+
+	if err := initializers.DB.Create(&milestone).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go routines.MarkMilestoneHistory(parsedProjectID, getActualUserID(c), 15, milestone.ID)
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":    "success",
+		"message":   "",
+		"milestone": milestone,
+	})
+}
+
+func UpdateMilestone(c *fiber.Ctx) error {
+	var reqBody schemas.MilestoneUpdateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.MilestoneUpdateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	milestone, err := getMilestoneFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	completed := false
+
+	if reqBody.Title != "" {
+		milestone.Title = reqBody.Title
+	}
+	if reqBody.Description != "" {
+		milestone.Description = reqBody.Description
+	}
+	if !reqBody.DueDate.IsZero() {
+		milestone.DueDate = reqBody.DueDate
+	}
+	if reqBody.Status != "" && reqBody.Status != milestone.Status {
+		milestone.CompletedAt = nil
+		if reqBody.Status == models.MilestoneCompleted {
+			now := time.Now()
+			milestone.CompletedAt = &now
+			completed = true
+		}
+		milestone.Status = reqBody.Status
+	}
+
+	if err := initializers.DB.Save(milestone).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	memberID := getActualUserID(c)
+	if completed {
+		go routines.MarkMilestoneHistory(milestone.ProjectID, memberID, 16, milestone.ID)
+		go routines.SendMilestoneNotification(memberID, milestone.ProjectID)
+	} else {
+		go routines.MarkMilestoneHistory(milestone.ProjectID, memberID, 30, milestone.ID)
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":    "success",
+		"message":   "Milestone updated",
+		"milestone": milestone,
+	})
+}
+
+func DeleteMilestone(c *fiber.Ctx) error {
+	milestone, err := getMilestoneFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	if err := initializers.DB.Delete(milestone).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go routines.MarkProjectHistory(milestone.ProjectID, getActualUserID(c), 31, nil, nil, nil, nil, nil, milestone.Title)
+
+	return c.Status(204).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Milestone deleted",
+	})
+}
+
+// setMilestoneTasks links the tasks of the request to the milestone, or unlinks them from it.
+func setMilestoneTasks(c *fiber.Ctx, link bool) error {
+	var reqBody struct {
+		Tasks []string `json:"tasks"`
+	}
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if len(reqBody.Tasks) == 0 {
+		return &fiber.Error{Code: 400, Message: "No Tasks provided."}
+	}
+
+	taskIDs := make([]uuid.UUID, len(reqBody.Tasks))
+	for i, taskID := range reqBody.Tasks {
+		parsedTaskID, err := uuid.Parse(taskID)
+		if err != nil {
+			return &fiber.Error{Code: 400, Message: "Invalid Task ID."}
+		}
+		taskIDs[i] = parsedTaskID
+	}
+
+	milestone, err := getMilestoneFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	db := initializers.DB.Model(&models.Task{}).Where("id IN ? AND project_id = ?", taskIDs, milestone.ProjectID)
+
+	var result *gorm.DB
+	if link {
+		result = db.Update("milestone_id", milestone.ID)
+	} else {
+		result = db.Where("milestone_id = ?", milestone.ID).Update("milestone_id", nil)
+	}
+	if result.Error != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: result.Error.Error(), Err: result.Error}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Milestone tasks updated",
+		"noTasks": result.RowsAffected,
+	})
+}
+
+func LinkMilestoneTasks(c *fiber.Ctx) error {
+	return setMilestoneTasks(c, true)
+}
+
+func UnlinkMilestoneTasks(c *fiber.Ctx) error {
+	return setMilestoneTasks(c, false)
+}
@@ -3,6 +3,7 @@ package project_controllers
 import (
 	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/Pratham-Mishra04/interact/cache"
 	"github.com/Pratham-Mishra04/interact/config"
@@ -21,6 +22,17 @@ import (
 	"gorm.io/gorm"
 )
 
+// getActualUserID is the user making the request, the member of the organization in the org routes,
+// where the logged in user is set to the owner of the organization.
+func getActualUserID(c *fiber.Ctx) uuid.UUID {
+	userID := c.GetRespHeader("loggedInUserID")
+	if c.Params("orgID") != "" {
+		userID = c.GetRespHeader("orgMemberID")
+	}
+	parsedUserID, _ := uuid.Parse(userID)
+	return parsedUserID
+}
+
 func GetProject(c *fiber.Ctx) error {
 	slug := c.Params("slug")
 	loggedInUserID := c.GetRespHeader("loggedInUserID")
@@ -31,6 +43,7 @@ func GetProject(c *fiber.Ctx) error {
 		go routines.UpdateProjectViews(projectInCache)
 		if parsedLoggedInUserID != projectInCache.UserID {
 			go routines.UpdateLastViewedProject(parsedLoggedInUserID, projectInCache.ID)
+			go routines.MarkProjectViewer(projectInCache.ID, parsedLoggedInUserID)
 		}
 		return c.Status(200).JSON(fiber.Map{
 			"status":  "success",
@@ -40,7 +53,7 @@ func GetProject(c *fiber.Ctx) error {
 	}
 
 	var project models.Project
-	if err := initializers.DB.Preload("User").Preload("Openings").Preload("Memberships").First(&project, "slug = ? AND is_private = ? ", slug, false).Error; err != nil {
+	if err := initializers.DB.Preload("User").Preload("Openings").Preload("Memberships").Preload("LinkPreviews").First(&project, "slug = ? AND is_private = ? ", slug, false).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return &fiber.Error{Code: 400, Message: "No Project of this ID found."}
 		}
@@ -56,6 +69,7 @@ func GetProject(c *fiber.Ctx) error {
 
 	if parsedLoggedInUserID != project.UserID {
 		go routines.UpdateLastViewedProject(parsedLoggedInUserID, project.ID)
+		go routines.MarkProjectViewer(project.ID, parsedLoggedInUserID)
 	}
 
 	_, count, err := utils.GetProjectViews(project.ID)
@@ -134,14 +148,16 @@ func GetProjectHistory(c *fiber.Ctx) error {
 	paginatedDB := API.Paginator(c)(initializers.DB)
 	var history []models.ProjectHistory
 
-	if err := paginatedDB.
-		Preload("Sender").
-		Preload("User").
+	if err := preloadProjectActivity(paginatedDB).
 		Where("project_id=?", projectID).
 		Order("created_at DESC").
 		Find(&history).Error; err != nil {
 		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 	}
+
+	for i := range history {
+		history[i].SetActivity()
+	}
 	return c.Status(200).JSON(fiber.Map{
 		"status":  "success",
 		"history": history,
@@ -313,6 +329,28 @@ func AddProject(c *fiber.Ctx) error {
 		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
 	}
 
+	var template *models.ProjectTemplate
+	if reqBody.TemplateID != "" {
+		var err error
+		template, err = getUsableTemplate(reqBody.TemplateID, getActualUserID(c), true)
+		if err != nil {
+			return err
+		}
+
+		if reqBody.Description == "" {
+			reqBody.Description = template.Description
+		}
+		if len(reqBody.Tags) == 0 {
+			reqBody.Tags = template.Tags
+		}
+		if reqBody.Category == "" {
+			reqBody.Category = template.Category
+		}
+		if len(reqBody.Links) == 0 {
+			reqBody.Links = template.Links
+		}
+	}
+
 	if err := helpers.Validate[schemas.ProjectCreateSchema](reqBody); err != nil {
 		return &fiber.Error{Code: 400, Message: err.Error()}
 	}
@@ -372,9 +410,16 @@ func AddProject(c *fiber.Ctx) error {
 					newProject.NumberOfMembers = 0
 				}
 
-				result := initializers.DB.Create(&newProject)
-				if result.Error != nil {
-					return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: result.Error.Error(), Err: result.Error}
+				if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+					if err := tx.Create(&newProject).Error; err != nil {
+						return err
+					}
+					if template != nil {
+						return createFromTemplate(tx, &newProject, template, getActualUserID(c))
+					}
+					return nil
+				}); err != nil {
+					return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
 				}
 
 				if orgMemberID != "" && orgID != "" {
@@ -389,6 +434,7 @@ func AddProject(c *fiber.Ctx) error {
 
 				go routines.IncrementUserProject(parsedID)
 				routines.GetImageBlurHash(c, "coverPic", &newProject)
+				go routines.StoreLinkPreviews(models.LinkPreview{ProjectID: &newProject.ID}, strings.Join(newProject.Links, " "))
 
 				return c.Status(201).JSON(fiber.Map{
 					"status":  "success",
@@ -475,6 +521,10 @@ func UpdateProject(c *fiber.Ctx) error {
 
 	routines.GetImageBlurHash(c, "coverPic", &project)
 
+	if reqBody.Links != nil {
+		go routines.StoreLinkPreviews(models.LinkPreview{ProjectID: &project.ID}, strings.Join(project.Links, " "))
+	}
+
 	go cache.RemoveProject(project.Slug)
 	go cache.RemoveProject("-workspace--" + project.Slug)
 
@@ -530,6 +580,15 @@ func DeleteProject(c *fiber.Ctx) error {
 
 	coverPic := project.CoverPic
 
+	//* the file versions go with the project in the cascade, their paths are needed to clear the bucket after
+	var filePaths []string
+	if err := initializers.DB.Model(&models.ProjectFileVersion{}).
+		Joins("JOIN project_files ON project_files.id = project_file_versions.file_id").
+		Where("project_files.project_id = ?", parsedProjectID).
+		Pluck("project_file_versions.path", &filePaths).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
 	tx := initializers.DB.Begin()
 	if tx.Error != nil {
 		return tx.Error
@@ -579,6 +638,7 @@ func DeleteProject(c *fiber.Ctx) error {
 	}
 
 	go routines.DeleteFromBucket(helpers.ProjectClient, coverPic)
+	go routines.DeleteProjectFiles(filePaths)
 	go routines.DecrementUserProject(parsedLoggedInUserID)
 
 	return c.Status(204).JSON(fiber.Map{
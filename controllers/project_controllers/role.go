@@ -0,0 +1,212 @@
+package project_controllers
+
+import (
+	"strings"
+
+	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/schemas"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+// getOwnedProjectRole finds the role of the params in a project of the logged in user, only owners can define roles.
+func getOwnedProjectRole(c *fiber.Ctx) (*models.ProjectRoleDefinition, *models.Project, error) {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	parsedRoleID, err := uuid.Parse(c.Params("roleID"))
+	if err != nil {
+		return nil, nil, &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var role models.ProjectRoleDefinition
+	if err := initializers.DB.First(&role, "id = ?", parsedRoleID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, &fiber.Error{Code: 400, Message: "No Role of this ID found."}
+		}
+		return nil, nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	var project models.Project
+	if err := initializers.DB.First(&project, "id = ? AND user_id = ?", role.ProjectID, loggedInUserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil, &fiber.Error{Code: 403, Message: "Only the owner of the project can manage its roles."}
+		}
+		return nil, nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return &role, &project, nil
+}
+
+func GetProjectRoles(c *fiber.Ctx) error {
+	projectID := c.Params("projectID")
+
+	var roles []models.ProjectRoleDefinition
+	if err := initializers.DB.Where("project_id = ?", projectID).Order("is_default DESC, created_at ASC").Find(&roles).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status": "success",
+		"roles":  roles,
+	})
+}
+
+func AddProjectRole(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var reqBody schemas.ProjectRoleCreateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.ProjectRoleCreateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	var project models.Project
+	if err := initializers.DB.First(&project, "id = ? AND user_id = ?", parsedProjectID, loggedInUserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Project of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	name := models.ProjectRole(strings.TrimSpace(reqBody.Name))
+
+	var count int64
+	if err := initializers.DB.Model(&models.ProjectRoleDefinition{}).Where("project_id = ? AND name = ?", project.ID, name).Count(&count).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	if _, isDefault := models.DefaultProjectRoles[name]; count > 0 || isDefault {
+		return &fiber.Error{Code: 400, Message: "A Role of this name already exists."}
+	}
+
+	role := models.ProjectRoleDefinition{
+		ProjectID:   project.ID,
+		Name:        name,
+		Permissions: pq.StringArray(reqBody.Permissions),
+	}
+
+	if err := initializers.DB.Create(&role).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go cache.RemoveProject(project.Slug)
+	go cache.RemoveProject("-workspace--" + project.Slug)
+	go cache.RemoveProject("-access--" + project.ID.String())
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Role created",
+		"role":    role,
+	})
+}
+
+// UpdateProjectRole changes the permissions of a role, custom roles can be renamed too, moving their members along.
+func UpdateProjectRole(c *fiber.Ctx) error {
+	var reqBody schemas.ProjectRoleUpdateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.ProjectRoleUpdateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	role, project, err := getOwnedProjectRole(c)
+	if err != nil {
+		return err
+	}
+
+	oldName := role.Name
+	name := models.ProjectRole(strings.TrimSpace(reqBody.Name))
+
+	if name != "" && name != role.Name {
+		if role.IsDefault {
+			return &fiber.Error{Code: 400, Message: "Default Roles cannot be renamed."}
+		}
+
+		var count int64
+		if err := initializers.DB.Model(&models.ProjectRoleDefinition{}).Where("project_id = ? AND name = ?", role.ProjectID, name).Count(&count).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		if _, isDefault := models.DefaultProjectRoles[name]; count > 0 || isDefault {
+			return &fiber.Error{Code: 400, Message: "A Role of this name already exists."}
+		}
+
+		role.Name = name
+	}
+	if reqBody.Permissions != nil {
+		role.Permissions = pq.StringArray(reqBody.Permissions)
+	}
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(role).Select("name", "permissions").Updates(role).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if role.Name != oldName {
+			if err := tx.Model(&models.Membership{}).Where("project_id = ? AND role = ?", role.ProjectID, oldName).Update("role", role.Name).Error; err != nil {
+				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+			}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	go cache.RemoveProject(project.Slug)
+	go cache.RemoveProject("-workspace--" + project.Slug)
+	go cache.RemoveProject("-access--" + project.ID.String())
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Role updated",
+		"role":    role,
+	})
+}
+
+// DeleteProjectRole deletes a custom role, its members are moved to the Member role.
+func DeleteProjectRole(c *fiber.Ctx) error {
+	role, project, err := getOwnedProjectRole(c)
+	if err != nil {
+		return err
+	}
+
+	if role.IsDefault {
+		return &fiber.Error{Code: 400, Message: "Default Roles cannot be deleted."}
+	}
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Membership{}).Where("project_id = ? AND role = ?", role.ProjectID, role.Name).Update("role", models.ProjectMember).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if err := tx.Delete(role).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	go cache.RemoveProject(project.Slug)
+	go cache.RemoveProject("-workspace--" + project.Slug)
+	go cache.RemoveProject("-access--" + project.ID.String())
+
+	return c.Status(204).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Role deleted",
+	})
+}
@@ -0,0 +1,305 @@
+package project_controllers
+
+import (
+	"math"
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/schemas"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// relativeDeadlineDays is the number of days between the creation of the project and the deadline, never negative.
+func relativeDeadlineDays(createdAt time.Time, deadline time.Time) int {
+	days := int(math.Ceil(deadline.Sub(createdAt).Hours() / 24))
+	if days < 0 {
+		return 0
+	}
+	return days
+}
+
+// getUsableTemplate finds a template which the user saved or which is in the library of one of their organizations.
+func getUsableTemplate(templateID string, userID uuid.UUID, populate bool) (*models.ProjectTemplate, error) {
+	parsedTemplateID, err := uuid.Parse(templateID)
+	if err != nil {
+		return nil, &fiber.Error{Code: 400, Message: "Invalid Template ID."}
+	}
+
+	db := initializers.DB
+	if populate {
+		db = db.Preload("Openings").Preload("Tasks").Preload("Tasks.SubTasks").Preload("Chats")
+	}
+
+	var template models.ProjectTemplate
+	if err := db.First(&template, "id = ?", parsedTemplateID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, &fiber.Error{Code: 400, Message: "No Template of this ID found."}
+		}
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if template.UserID == userID {
+		return &template, nil
+	}
+
+	if template.OrganizationID != nil {
+		var count int64
+		if err := initializers.DB.Model(&models.Organization{}).
+			Where("id = ? AND (user_id = ? OR id IN (SELECT organization_id FROM organization_memberships WHERE user_id = ?))", template.OrganizationID, userID, userID).
+			Count(&count).Error; err != nil {
+			return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		if count > 0 {
+			return &template, nil
+		}
+	}
+
+	return nil, &fiber.Error{Code: 403, Message: "You do not have the permission to use this template."}
+}
+
+// createFromTemplate adds the openings, tasks and group chats of the template to the new project.
+func createFromTemplate(tx *gorm.DB, project *models.Project, template *models.ProjectTemplate, creatorID uuid.UUID) error {
+	for _, templateOpening := range template.Openings {
+		opening := models.Opening{
+			ProjectID:   project.ID,
+			Title:       templateOpening.Title,
+			Description: templateOpening.Description,
+			Tags:        templateOpening.Tags,
+			Active:      templateOpening.Active,
+			UserID:      project.UserID,
+		}
+		if err := tx.Create(&opening).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, templateTask := range template.Tasks {
+		task := models.Task{
+			ProjectID:   &project.ID,
+			Title:       templateTask.Title,
+			Description: templateTask.Description,
+			Tags:        templateTask.Tags,
+			Priority:    templateTask.Priority,
+			Deadline:    project.CreatedAt.AddDate(0, 0, templateTask.DeadlineDays),
+		}
+		for _, templateSubTask := range templateTask.SubTasks {
+			task.SubTasks = append(task.SubTasks, models.SubTask{
+				Title:       templateSubTask.Title,
+				Description: templateSubTask.Description,
+				Tags:        templateSubTask.Tags,
+				Priority:    templateSubTask.Priority,
+				Deadline:    project.CreatedAt.AddDate(0, 0, templateSubTask.DeadlineDays),
+			})
+		}
+		if err := tx.Create(&task).Error; err != nil {
+			return err
+		}
+	}
+
+	for _, templateChat := range template.Chats {
+		chat := models.GroupChat{
+			UserID:      creatorID,
+			Title:       templateChat.Title,
+			Description: templateChat.Description,
+			AdminOnly:   templateChat.AdminOnly,
+			ProjectID:   &project.ID,
+		}
+		if err := tx.Create(&chat).Error; err != nil {
+			return err
+		}
+
+		chatMembership := models.GroupChatMembership{
+			UserID:      creatorID,
+			GroupChatID: chat.ID,
+			Role:        models.ChatAdmin,
+		}
+		if err := tx.Create(&chatMembership).Error; err != nil {
+			return err
+		}
+	}
+
+	return tx.Model(template).UpdateColumn("no_uses", gorm.Expr("no_uses + 1")).Error
+}
+
+// SaveProjectTemplate saves a project of the logged in user as a template, to the library of the organization in the org routes.
+func SaveProjectTemplate(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var reqBody schemas.ProjectTemplateCreateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.ProjectTemplateCreateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	var project models.Project
+	if err := initializers.DB.
+		Preload("Openings").
+		Preload("Tasks").
+		Preload("Tasks.SubTasks").
+		Preload("Chats").
+		First(&project, "id = ? AND user_id = ?", parsedProjectID, loggedInUserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Project of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	template := models.ProjectTemplate{
+		Name:            reqBody.Name,
+		About:           reqBody.About,
+		UserID:          getActualUserID(c),
+		SourceProjectID: &project.ID,
+		Description:     project.Description,
+		Tags:            project.Tags,
+		Category:        project.Category,
+		Links:           project.Links,
+	}
+
+	if orgID := c.Params("orgID"); orgID != "" {
+		parsedOrgID, err := uuid.Parse(orgID)
+		if err != nil {
+			return &fiber.Error{Code: 400, Message: "Invalid Organization ID."}
+		}
+		template.OrganizationID = &parsedOrgID
+	}
+
+	for _, opening := range project.Openings {
+		template.Openings = append(template.Openings, models.ProjectTemplateOpening{
+			Title:       opening.Title,
+			Description: opening.Description,
+			Tags:        opening.Tags,
+			Active:      opening.Active,
+		})
+	}
+
+	for _, task := range project.Tasks {
+		templateTask := models.ProjectTemplateTask{
+			Title:        task.Title,
+			Description:  task.Description,
+			Tags:         task.Tags,
+			Priority:     task.Priority,
+			DeadlineDays: relativeDeadlineDays(project.CreatedAt, task.Deadline),
+		}
+		for _, subTask := range task.SubTasks {
+			templateTask.SubTasks = append(templateTask.SubTasks, models.ProjectTemplateSubTask{
+				Title:        subTask.Title,
+				Description:  subTask.Description,
+				Tags:         subTask.Tags,
+				Priority:     subTask.Priority,
+				DeadlineDays: relativeDeadlineDays(project.CreatedAt, subTask.Deadline),
+			})
+		}
+		template.Tasks = append(template.Tasks, templateTask)
+	}
+
+	for _, chat := range project.Chats {
+		template.Chats = append(template.Chats, models.ProjectTemplateChat{
+			Title:       chat.Title,
+			Description: chat.Description,
+			AdminOnly:   chat.AdminOnly,
+		})
+	}
+
+	if err := initializers.DB.Create(&template).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":   "success",
+		"message":  "Template saved",
+		"template": template,
+	})
+}
+
+func GetMyProjectTemplates(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	var templates []models.ProjectTemplate
+	if err := initializers.DB.
+		Where("user_id = ? AND organization_id IS NULL", loggedInUserID).
+		Order("created_at DESC").
+		Find(&templates).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":    "success",
+		"templates": templates,
+	})
+}
+
+// GetOrgProjectTemplates gets the template library of the organization.
+func GetOrgProjectTemplates(c *fiber.Ctx) error {
+	orgID := c.Params("orgID")
+
+	var templates []models.ProjectTemplate
+	if err := initializers.DB.
+		Preload("User").
+		Where("organization_id = ?", orgID).
+		Order("no_uses DESC, created_at DESC").
+		Find(&templates).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":    "success",
+		"templates": templates,
+	})
+}
+
+func GetProjectTemplate(c *fiber.Ctx) error {
+	template, err := getUsableTemplate(c.Params("templateID"), getActualUserID(c), true)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":   "success",
+		"template": template,
+	})
+}
+
+// DeleteProjectTemplate deletes a template of the logged in user, or one from the library of the organization in the org routes.
+func DeleteProjectTemplate(c *fiber.Ctx) error {
+	parsedTemplateID, err := uuid.Parse(c.Params("templateID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	db := initializers.DB.Where("id = ?", parsedTemplateID)
+	if orgID := c.Params("orgID"); orgID != "" {
+		db = db.Where("organization_id = ?", orgID)
+	} else {
+		db = db.Where("user_id = ? AND organization_id IS NULL", c.GetRespHeader("loggedInUserID"))
+	}
+
+	var template models.ProjectTemplate
+	if err := db.First(&template).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Template of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := initializers.DB.Delete(&template).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(204).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Template deleted",
+	})
+}
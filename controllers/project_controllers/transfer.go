@@ -0,0 +1,298 @@
+package project_controllers
+
+import (
+	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/controllers/auth_controllers"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func transferOtpKey(userID uuid.UUID, projectID uuid.UUID) string {
+	return userID.String() + "-transfer-" + projectID.String()
+}
+
+func getTransferFromParams(c *fiber.Ctx) (*models.ProjectOwnershipTransfer, error) {
+	parsedTransferID, err := uuid.Parse(c.Params("transferID"))
+	if err != nil {
+		return nil, &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var transfer models.ProjectOwnershipTransfer
+	if err := initializers.DB.Preload("Project").First(&transfer, "id = ? AND status = 0", parsedTransferID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, &fiber.Error{Code: 400, Message: "No Pending Transfer of this ID found."}
+		}
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return &transfer, nil
+}
+
+func GetMyOwnershipTransfers(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	var transfers []models.ProjectOwnershipTransfer
+	if err := initializers.DB.
+		Preload("Project").
+		Preload("FromUser").
+		Where("(to_user_id = ? OR from_user_id = ?) AND status = 0", loggedInUserID, loggedInUserID).
+		Order("created_at DESC").
+		Find(&transfers).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":    "success",
+		"transfers": transfers,
+	})
+}
+
+func SendTransferVerificationCode(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var user models.User
+	if err := initializers.DB.Where("id=?", loggedInUserID).First(&user).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	var project models.Project
+	if err := initializers.DB.First(&project, "id = ? AND user_id=?", parsedProjectID, user.ID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Project of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	code := auth_controllers.GenerateOTP(6)
+	hash, err := bcrypt.GenerateFromPassword([]byte(code), 10)
+	if err != nil {
+		go helpers.LogServerError("Error while hashing an OTP.", err, c.Path())
+		return helpers.AppError{Code: 500, Message: config.SERVER_ERROR, Err: err}
+	}
+	err = helpers.SendMail(config.VERIFICATION_TRANSFER_PROJECT_SUBJECT, config.VERIFICATION_EMAIL_BODY+code, user.Name, user.Email, "<div><strong>This is Valid for next 10 minutes only!</strong></div>")
+	if err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	err = cache.SetOtpToCache(transferOtpKey(user.ID, project.ID), []byte(hash))
+	if err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "OTP sent to registered mail",
+	})
+}
+
+// TransferProject asks a manager of the project to become its owner, the transfer is complete once they accept it.
+func TransferProject(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+	parsedLoggedInUserID, _ := uuid.Parse(loggedInUserID)
+
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var reqBody struct {
+		UserID           string `json:"userID"`
+		VerificationCode string `json:"otp"`
+	}
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	parsedUserID, err := uuid.Parse(reqBody.UserID)
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid User ID."}
+	}
+
+	var project models.Project
+	if err := initializers.DB.First(&project, "id = ? AND user_id=?", parsedProjectID, parsedLoggedInUserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Project of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	data, err := cache.GetOtpFromCache(transferOtpKey(parsedLoggedInUserID, project.ID))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "OTP Expired"}
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(data), []byte(reqBody.VerificationCode)); err != nil {
+		if err := cache.IncrementOtpAttempts(transferOtpKey(parsedLoggedInUserID, project.ID)); err != nil {
+			return helpers.AppError{Code: 500, Message: config.SERVER_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		return &fiber.Error{Code: 400, Message: "Incorrect OTP"}
+	}
+
+	var membership models.Membership
+	if err := initializers.DB.First(&membership, "project_id = ? AND user_id = ?", project.ID, parsedUserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "User is not a member of this project."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if membership.Role != models.ProjectManager {
+		return &fiber.Error{Code: 400, Message: "Ownership can only be transferred to a Manager of the project."}
+	}
+
+	var existingTransfer models.ProjectOwnershipTransfer
+	if err := initializers.DB.First(&existingTransfer, "project_id = ? AND status = 0", project.ID).Error; err == nil {
+		return &fiber.Error{Code: 400, Message: "A transfer of this project is already pending."}
+	} else if err != gorm.ErrRecordNotFound {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	transfer := models.ProjectOwnershipTransfer{
+		ProjectID:  project.ID,
+		FromUserID: parsedLoggedInUserID,
+		ToUserID:   parsedUserID,
+	}
+	if err := initializers.DB.Create(&transfer).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go cache.RemoveOtpFromCache(transferOtpKey(parsedLoggedInUserID, project.ID))
+	go routines.SendOwnershipTransferNotification(parsedUserID, parsedLoggedInUserID, project.ID)
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":   "success",
+		"message":  "Transfer request sent to the user.",
+		"transfer": transfer,
+	})
+}
+
+func CancelOwnershipTransfer(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	transfer, err := getTransferFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	if transfer.FromUserID.String() != loggedInUserID {
+		return &fiber.Error{Code: 403, Message: "You do not have the permission to perform this action."}
+	}
+
+	if err := initializers.DB.Delete(transfer).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(204).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Transfer cancelled.",
+	})
+}
+
+func RejectOwnershipTransfer(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	transfer, err := getTransferFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	if transfer.ToUserID.String() != loggedInUserID {
+		return &fiber.Error{Code: 403, Message: "You do not have the permission to perform this action."}
+	}
+
+	transfer.Status = -1
+	if err := initializers.DB.Model(transfer).Update("status", transfer.Status).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Transfer rejected.",
+	})
+}
+
+// AcceptOwnershipTransfer makes the user the owner of the project, and the previous owner a manager of it.
+func AcceptOwnershipTransfer(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	transfer, err := getTransferFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	if transfer.ToUserID.String() != loggedInUserID {
+		return &fiber.Error{Code: 403, Message: "You do not have the permission to perform this action."}
+	}
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		var project models.Project
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&project, "id = ?", transfer.ProjectID).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if project.UserID != transfer.FromUserID {
+			return &fiber.Error{Code: 400, Message: "The project has a different owner now."}
+		}
+
+		var membership models.Membership
+		if err := tx.First(&membership, "project_id = ? AND user_id = ?", project.ID, transfer.ToUserID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return &fiber.Error{Code: 400, Message: "You are no longer a member of this project."}
+			}
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if membership.Role != models.ProjectManager {
+			return &fiber.Error{Code: 400, Message: "You are no longer a Manager of this project."}
+		}
+
+		//* the previous owner stays on as a manager, keeping the number of members unchanged
+		membership.UserID = transfer.FromUserID
+		membership.Title = "Former Owner"
+		if err := tx.Save(&membership).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if err := tx.Model(&project).Update("user_id", transfer.ToUserID).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		//* openings are held by the owner, who decides on their applications
+		if err := tx.Model(&models.Opening{}).Where("project_id = ? AND user_id = ?", project.ID, transfer.FromUserID).Update("user_id", transfer.ToUserID).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if err := tx.Model(transfer).Update("status", 1).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	go routines.MarkProjectHistory(transfer.ProjectID, transfer.FromUserID, 12, &transfer.ToUserID, nil, nil, nil, nil, "")
+	go routines.SendOwnershipAcceptedNotification(transfer.FromUserID, transfer.ToUserID, transfer.ProjectID)
+	go routines.TransferUserProject(transfer.FromUserID, transfer.ToUserID)
+	go cache.RemoveProject(transfer.Project.Slug)
+	go cache.RemoveProject("-workspace--" + transfer.Project.Slug)
+	go cache.RemoveProject("-access--" + transfer.ProjectID.String())
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "You are now the owner of this project.",
+	})
+}
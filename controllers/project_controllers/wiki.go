@@ -0,0 +1,421 @@
+package project_controllers
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/routines"
+	"github.com/Pratham-Mishra04/interact/schemas"
+	"github.com/Pratham-Mishra04/interact/utils"
+	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"github.com/gosimple/slug"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func getWikiPageFromParams(c *fiber.Ctx) (*models.WikiPage, error) {
+	parsedPageID, err := uuid.Parse(c.Params("pageID"))
+	if err != nil {
+		return nil, &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var page models.WikiPage
+	if err := initializers.DB.First(&page, "id = ?", parsedPageID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, &fiber.Error{Code: 400, Message: "No Page of this ID found."}
+		}
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return &page, nil
+}
+
+// setWikiContent sets the markdown of the page along with its rendered HTML and the pages it links to.
+func setWikiContent(page *models.WikiPage, content string) error {
+	html, err := utils.RenderWiki(content)
+	if err != nil {
+		return helpers.AppError{Code: 500, Message: config.SERVER_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	page.Content = content
+	page.HTML = html
+	page.Links = utils.ParseWikiLinks(content)
+	return nil
+}
+
+// getWikiBacklinks gets the pages of the project linking to the page.
+func getWikiBacklinks(page *models.WikiPage, publicOnly bool) ([]models.WikiPage, error) {
+	db := initializers.DB.Select("id", "title", "slug").Where("project_id = ? AND ? = ANY(links) AND id <> ?", page.ProjectID, page.Slug, page.ID)
+	if publicOnly {
+		db = db.Where("is_public = ?", true)
+	}
+
+	var backlinks []models.WikiPage
+	if err := db.Order("title ASC").Find(&backlinks).Error; err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	return backlinks, nil
+}
+
+// addWikiRevision saves a copy of the title and content of the page as its latest revision.
+func addWikiRevision(tx *gorm.DB, page *models.WikiPage) error {
+	revision := models.WikiRevision{
+		PageID:     page.ID,
+		Revision:   page.LatestRevision,
+		Title:      page.Title,
+		Content:    page.Content,
+		EditedByID: page.LastEditedByID,
+	}
+	return tx.Create(&revision).Error
+}
+
+// searchWikiPages lists the pages of the project without their content, matching the search query if there is one.
+func searchWikiPages(c *fiber.Ctx, projectID uuid.UUID, publicOnly bool) ([]models.WikiPage, error) {
+	db := API.Search(c, 8)(initializers.DB).
+		Preload("LastEditedBy").
+		Omit("content", "html").
+		Where("project_id = ?", projectID)
+	if publicOnly {
+		db = db.Where("is_public = ?", true)
+	}
+
+	var pages []models.WikiPage
+	if err := db.Order("title ASC").Find(&pages).Error; err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	return pages, nil
+}
+
+func GetProjectWikiPages(c *fiber.Ctx) error {
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	pages, err := searchWikiPages(c, parsedProjectID, false)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status": "success",
+		"pages":  pages,
+	})
+}
+
+func GetWikiPage(c *fiber.Ctx) error {
+	parsedPageID, err := uuid.Parse(c.Params("pageID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var page models.WikiPage
+	if err := initializers.DB.Preload("CreatedBy").Preload("LastEditedBy").First(&page, "id = ?", parsedPageID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Page of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	backlinks, err := getWikiBacklinks(&page, false)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":    "success",
+		"page":      page,
+		"backlinks": backlinks,
+	})
+}
+
+func AddWikiPage(c *fiber.Ctx) error {
+	parsedProjectID, err := uuid.Parse(c.Params("projectID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var reqBody schemas.WikiPageCreateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.WikiPageCreateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	pageSlug := slug.Make(reqBody.Slug)
+	if pageSlug == "" {
+		pageSlug = slug.Make(reqBody.Title)
+	}
+	if pageSlug == "" {
+		return &fiber.Error{Code: 400, Message: "Invalid Page Title."}
+	}
+
+	var count int64
+	if err := initializers.DB.Model(&models.WikiPage{}).Where("project_id = ? AND slug = ?", parsedProjectID, pageSlug).Count(&count).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	if count > 0 {
+		return &fiber.Error{Code: 400, Message: "A Page with this slug already exists in the wiki."}
+	}
+
+	memberID := getActualUserID(c)
+
+	page := models.WikiPage{
+		ProjectID:      parsedProjectID,
+		Title:          strings.TrimSpace(reqBody.Title),
+		Slug:           pageSlug,
+		IsPublic:       reqBody.IsPublic,
+		LatestRevision: 1,
+		CreatedByID:    memberID,
+		LastEditedByID: memberID,
+	}
+
+	if err := setWikiContent(&page, reqBody.Content); err != nil {
+		return err
+	}
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&page).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if err := addWikiRevision(tx, &page); err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	go routines.MarkProjectHistory(parsedProjectID, memberID, 22, nil, nil, nil, nil, nil, page.Title)
+
+	return c.Status(201).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Page created",
+		"page":    page,
+	})
+}
+
+func UpdateWikiPage(c *fiber.Ctx) error {
+	var reqBody schemas.WikiPageUpdateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Req Body"}
+	}
+
+	if err := helpers.Validate[schemas.WikiPageUpdateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	page, err := getWikiPageFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	edited := false
+
+	if title := strings.TrimSpace(reqBody.Title); title != "" && title != page.Title {
+		page.Title = title
+		edited = true
+	}
+	if reqBody.Content != nil && *reqBody.Content != page.Content {
+		if err := setWikiContent(page, *reqBody.Content); err != nil {
+			return err
+		}
+		edited = true
+	}
+	if reqBody.IsPublic != nil {
+		page.IsPublic = *reqBody.IsPublic
+	}
+
+	page.LastEditedByID = getActualUserID(c)
+	page.UpdatedAt = time.Now()
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		columns := []string{"title", "content", "html", "links", "is_public", "last_edited_by_id", "updated_at"}
+
+		if edited {
+			//* the page row is locked so that parallel saves are numbered one after the other
+			var lockedPage models.WikiPage
+			if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Select("id", "latest_revision").First(&lockedPage, "id = ?", page.ID).Error; err != nil {
+				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+			}
+			page.LatestRevision = lockedPage.LatestRevision + 1
+			if err := addWikiRevision(tx, page); err != nil {
+				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+			}
+			columns = append(columns, "latest_revision")
+		}
+
+		if err := tx.Model(page).Select(columns).Updates(page).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Page updated",
+		"page":    page,
+	})
+}
+
+func DeleteWikiPage(c *fiber.Ctx) error {
+	page, err := getWikiPageFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	if err := initializers.DB.Delete(page).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	go routines.MarkProjectHistory(page.ProjectID, getActualUserID(c), 23, nil, nil, nil, nil, nil, page.Title)
+
+	return c.Status(204).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Page deleted",
+	})
+}
+
+func GetWikiRevisions(c *fiber.Ctx) error {
+	page, err := getWikiPageFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	paginatedDB := API.Paginator(c)(initializers.DB)
+
+	var revisions []models.WikiRevision
+	if err := paginatedDB.
+		Preload("EditedBy").
+		Where("page_id = ?", page.ID).
+		Order("revision DESC").
+		Find(&revisions).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":    "success",
+		"revisions": revisions,
+	})
+}
+
+// RestoreWikiRevision brings back the title and content of an older revision, as a new revision of the page.
+func RestoreWikiRevision(c *fiber.Ctx) error {
+	page, err := getWikiPageFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	revisionNo, err := c.ParamsInt("revision")
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Revision."}
+	}
+
+	var revision models.WikiRevision
+	if err := initializers.DB.First(&revision, "page_id = ? AND revision = ?", page.ID, revisionNo).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Revision of this Page found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if revision.Revision == page.LatestRevision {
+		return &fiber.Error{Code: 400, Message: "This is already the latest revision."}
+	}
+
+	page.Title = revision.Title
+	if err := setWikiContent(page, revision.Content); err != nil {
+		return err
+	}
+	page.LastEditedByID = getActualUserID(c)
+	page.UpdatedAt = time.Now()
+	page.LatestRevision++
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := addWikiRevision(tx, page); err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if err := tx.Model(page).Select("title", "content", "html", "links", "latest_revision", "last_edited_by_id", "updated_at").Updates(page).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "Revision restored",
+		"page":    page,
+	})
+}
+
+func getPublicWikiProject(c *fiber.Ctx) (*models.Project, error) {
+	var project models.Project
+	if err := initializers.DB.First(&project, "slug = ? AND is_private = ?", c.Params("slug"), false).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, &fiber.Error{Code: 400, Message: "No Project of this ID found."}
+		}
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	return &project, nil
+}
+
+// GetPublicWikiPages lists the public pages of the wiki of a public project.
+func GetPublicWikiPages(c *fiber.Ctx) error {
+	project, err := getPublicWikiProject(c)
+	if err != nil {
+		return err
+	}
+
+	pages, err := searchWikiPages(c, project.ID, true)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status": "success",
+		"pages":  pages,
+	})
+}
+
+func GetPublicWikiPage(c *fiber.Ctx) error {
+	project, err := getPublicWikiProject(c)
+	if err != nil {
+		return err
+	}
+
+	var page models.WikiPage
+	if err := initializers.DB.
+		Preload("CreatedBy").
+		Preload("LastEditedBy").
+		First(&page, "project_id = ? AND slug = ? AND is_public = ?", project.ID, c.Params("pageSlug"), true).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Page of this slug found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	backlinks, err := getWikiBacklinks(&page, true)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":    "success",
+		"page":      page,
+		"backlinks": backlinks,
+	})
+}
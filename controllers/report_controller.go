@@ -7,8 +7,10 @@ import (
 	"github.com/Pratham-Mishra04/interact/models"
 	"github.com/Pratham-Mishra04/interact/routines"
 	"github.com/Pratham-Mishra04/interact/schemas"
+	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 func AddReport(c *fiber.Ctx) error {
@@ -29,6 +31,8 @@ func AddReport(c *fiber.Ctx) error {
 		initializers.DB.Where("reporter_id=? AND user_id=?", parsedLoggedInUserID, reqBody.UserID).First(&existingReport)
 	} else if reqBody.PostID != "" {
 		initializers.DB.Where("reporter_id=? AND post_id=?", parsedLoggedInUserID, reqBody.PostID).First(&existingReport)
+	} else if reqBody.CommentID != "" {
+		initializers.DB.Where("reporter_id=? AND comment_id=?", parsedLoggedInUserID, reqBody.CommentID).First(&existingReport)
 	} else if reqBody.ProjectID != "" {
 		initializers.DB.Where("reporter_id=? AND project_id=?", parsedLoggedInUserID, reqBody.ProjectID).First(&existingReport)
 	} else if reqBody.EventID != "" {
@@ -64,6 +68,12 @@ func AddReport(c *fiber.Ctx) error {
 			return &fiber.Error{Code: 400, Message: "Invalid Post ID"}
 		}
 		report.PostID = &parsedPostID
+	} else if reqBody.CommentID != "" {
+		parsedCommentID, err := uuid.Parse(reqBody.CommentID)
+		if err != nil {
+			return &fiber.Error{Code: 400, Message: "Invalid Comment ID"}
+		}
+		report.CommentID = &parsedCommentID
 	} else if reqBody.ProjectID != "" {
 		parsedProjectID, err := uuid.Parse(reqBody.ProjectID)
 		if err != nil {
@@ -102,3 +112,115 @@ func AddReport(c *fiber.Ctx) error {
 		"message": "Reported",
 	})
 }
+
+func preloadReport(db *gorm.DB) *gorm.DB {
+	return db.Preload("Reporter").
+		Preload("User").
+		Preload("Post").
+		Preload("Post.User").
+		Preload("Comment").
+		Preload("Comment.User").
+		Preload("Project").
+		Preload("Event").
+		Preload("Opening").
+		Preload("GroupChat")
+}
+
+// setOriginalContent fills in the text of the reported posts and comments as they were first written,
+// so that edits made after the report do not hide what was reported.
+func setOriginalContent(reports []models.Report) error {
+	var postIDs, commentIDs []uuid.UUID
+	for _, report := range reports {
+		if report.PostID != nil {
+			postIDs = append(postIDs, *report.PostID)
+		}
+		if report.CommentID != nil {
+			commentIDs = append(commentIDs, *report.CommentID)
+		}
+	}
+	if len(postIDs) == 0 && len(commentIDs) == 0 {
+		return nil
+	}
+
+	var revisions []models.Revision
+	if err := initializers.DB.Where("post_id IN ? OR comment_id IN ?", postIDs, commentIDs).Order("created_at ASC").Find(&revisions).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	originalContent := make(map[uuid.UUID]string)
+	for _, revision := range revisions {
+		ownerID := revision.PostID
+		if ownerID == nil {
+			ownerID = revision.CommentID
+		}
+		if _, ok := originalContent[*ownerID]; !ok {
+			originalContent[*ownerID] = revision.Content
+		}
+	}
+
+	for i, report := range reports {
+		if report.PostID != nil {
+			reports[i].OriginalContent = report.Post.Content
+			if content, ok := originalContent[*report.PostID]; ok {
+				reports[i].OriginalContent = content
+			}
+		} else if report.CommentID != nil {
+			reports[i].OriginalContent = report.Comment.Content
+			if content, ok := originalContent[*report.CommentID]; ok {
+				reports[i].OriginalContent = content
+			}
+		}
+	}
+
+	return nil
+}
+
+func GetReports(c *fiber.Ctx) error {
+	paginatedDB := API.Paginator(c)(initializers.DB)
+
+	db := preloadReport(paginatedDB)
+	if reportType := c.Query("reportType", ""); reportType != "" {
+		db = db.Where("report_type = ?", reportType)
+	}
+
+	var reports []models.Report
+	if err := db.Order("created_at DESC").Find(&reports).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if err := setOriginalContent(reports); err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "",
+		"reports": reports,
+	})
+}
+
+func GetReport(c *fiber.Ctx) error {
+	parsedReportID, err := uuid.Parse(c.Params("reportID"))
+	if err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	var report models.Report
+	if err := preloadReport(initializers.DB).First(&report, "id = ?", parsedReportID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Report of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	reports := []models.Report{report}
+	if err := setOriginalContent(reports); err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "",
+		"report":  reports[0],
+	})
+}
@@ -0,0 +1,228 @@
+package controllers
+
+import (
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// saveRevision stores the content being edited away, owner should have either the PostID or the CommentID set.
+// It is run in the same transaction as the edit, so that a revision is only kept if the edit is saved.
+func saveRevision(tx *gorm.DB, owner models.Revision, content string, createdAt time.Time) error {
+	var lastRevision models.Revision
+	if err := tx.Where(&owner).Order("created_at DESC").First(&lastRevision).Error; err != nil {
+		if err != gorm.ErrRecordNotFound {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		lastRevision.CreatedAt = createdAt
+	}
+
+	revision := owner
+	revision.Content = content
+	revision.WrittenAt = lastRevision.CreatedAt
+
+	if err := tx.Create(&revision).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	return nil
+}
+
+// getVersions returns all the stored revisions of owner, oldest first, followed by the current content.
+func getVersions(owner models.Revision, content string, createdAt time.Time) ([]models.Revision, error) {
+	var revisions []models.Revision
+	if err := initializers.DB.Where(&owner).Order("created_at ASC").Find(&revisions).Error; err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	current := owner
+	current.Content = content
+	current.WrittenAt = createdAt
+	if len(revisions) > 0 {
+		current.WrittenAt = revisions[len(revisions)-1].CreatedAt
+	}
+
+	return append(revisions, current), nil
+}
+
+func findVersion(versions []models.Revision, versionID string) (models.Revision, error) {
+	if versionID == "current" {
+		return versions[len(versions)-1], nil
+	}
+
+	parsedVersionID, err := uuid.Parse(versionID)
+	if err != nil {
+		return models.Revision{}, &fiber.Error{Code: 400, Message: "Invalid Revision ID."}
+	}
+
+	for _, version := range versions {
+		if version.ID == parsedVersionID {
+			return version, nil
+		}
+	}
+	return models.Revision{}, &fiber.Error{Code: 400, Message: "No Revision of this ID found."}
+}
+
+func sendVersionsDiff(c *fiber.Ctx, versions []models.Revision) error {
+	from := versions[0]
+	if fromID := c.Query("from", ""); fromID != "" {
+		version, err := findVersion(versions, fromID)
+		if err != nil {
+			return err
+		}
+		from = version
+	}
+
+	to, err := findVersion(versions, c.Query("to", "current"))
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":  "success",
+		"message": "",
+		"from":    from,
+		"to":      to,
+		"diff":    utils.DiffWords(from.Content, to.Content),
+	})
+}
+
+func getPostForRevisions(c *fiber.Ctx) (models.Post, error) {
+	var post models.Post
+
+	parsedPostID, err := uuid.Parse(c.Params("postID"))
+	if err != nil {
+		return post, &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	if err := initializers.DB.First(&post, "id = ?", parsedPostID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return post, &fiber.Error{Code: 400, Message: "No Post of this ID found."}
+		}
+		return post, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if post.Status != models.PostPublished && post.UserID.String() != c.GetRespHeader("loggedInUserID") {
+		return post, &fiber.Error{Code: 400, Message: "No Post of this ID found."}
+	}
+
+	if err := utils.CheckPostVisibility(&post, c.GetRespHeader("loggedInUserID")); err != nil {
+		return post, err
+	}
+
+	return post, nil
+}
+
+func getCommentForRevisions(c *fiber.Ctx) (models.Comment, error) {
+	var comment models.Comment
+
+	parsedCommentID, err := uuid.Parse(c.Params("commentID"))
+	if err != nil {
+		return comment, &fiber.Error{Code: 400, Message: "Invalid ID"}
+	}
+
+	if err := initializers.DB.First(&comment, "id = ?", parsedCommentID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return comment, &fiber.Error{Code: 400, Message: "No Comment of this ID found."}
+		}
+		return comment, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if comment.PostID != nil {
+		if err := utils.CheckPostIDVisibility(comment.PostID.String(), c.GetRespHeader("loggedInUserID")); err != nil {
+			return comment, err
+		}
+	}
+
+	//* the comments of a private project are only for its collaborators
+	if comment.ProjectID != nil {
+		var project models.Project
+		if err := initializers.DB.Select("id", "user_id", "is_private").First(&project, "id = ?", *comment.ProjectID).Error; err != nil {
+			return comment, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+
+		if project.IsPrivate && project.UserID.String() != c.GetRespHeader("loggedInUserID") {
+			var count int64
+			if err := initializers.DB.Model(&models.Membership{}).
+				Where("project_id = ? AND user_id = ?", project.ID, c.GetRespHeader("loggedInUserID")).
+				Count(&count).Error; err != nil {
+				return comment, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+			}
+			if count == 0 {
+				return comment, &fiber.Error{Code: 400, Message: "No Comment of this ID found."}
+			}
+		}
+	}
+
+	return comment, nil
+}
+
+func GetPostRevisions(c *fiber.Ctx) error {
+	post, err := getPostForRevisions(c)
+	if err != nil {
+		return err
+	}
+
+	versions, err := getVersions(models.Revision{PostID: &post.ID}, post.Content, post.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":    "success",
+		"message":   "",
+		"revisions": versions,
+	})
+}
+
+func GetPostRevisionsDiff(c *fiber.Ctx) error {
+	post, err := getPostForRevisions(c)
+	if err != nil {
+		return err
+	}
+
+	versions, err := getVersions(models.Revision{PostID: &post.ID}, post.Content, post.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return sendVersionsDiff(c, versions)
+}
+
+func GetCommentRevisions(c *fiber.Ctx) error {
+	comment, err := getCommentForRevisions(c)
+	if err != nil {
+		return err
+	}
+
+	versions, err := getVersions(models.Revision{CommentID: &comment.ID}, comment.Content, comment.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":    "success",
+		"message":   "",
+		"revisions": versions,
+	})
+}
+
+func GetCommentRevisionsDiff(c *fiber.Ctx) error {
+	comment, err := getCommentForRevisions(c)
+	if err != nil {
+		return err
+	}
+
+	versions, err := getVersions(models.Revision{CommentID: &comment.ID}, comment.Content, comment.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	return sendVersionsDiff(c, versions)
+}
@@ -118,6 +118,17 @@ func AddTask(taskType string) func(c *fiber.Ctx) error {
 				Priority:    reqBody.Priority,
 			}
 
+			if reqBody.MilestoneID != "" {
+				var milestone models.Milestone
+				if err := initializers.DB.First(&milestone, "id = ? AND project_id = ?", reqBody.MilestoneID, project.ID).Error; err != nil {
+					if err == gorm.ErrRecordNotFound {
+						return &fiber.Error{Code: 400, Message: "No Milestone of this ID found."}
+					}
+					return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+				}
+				task.MilestoneID = &milestone.ID
+			}
+
 			result := initializers.DB.Create(&task)
 			if result.Error != nil {
 				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: result.Error.Error(), Err: result.Error}
@@ -344,6 +355,7 @@ func MarkTaskCompleted(taskType string) func(c *fiber.Ctx) error {
 				return &fiber.Error{Code: 403, Message: "Cannot Perform this action"}
 			}
 
+			completed := reqBody.IsCompleted && !task.IsCompleted
 			task.IsCompleted = reqBody.IsCompleted
 
 			result := initializers.DB.Save(&task)
@@ -351,13 +363,18 @@ func MarkTaskCompleted(taskType string) func(c *fiber.Ctx) error {
 				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: result.Error.Error(), Err: result.Error}
 			}
 
+			if completed && task.ProjectID != nil {
+				parsedUserID, _ := uuid.Parse(userID)
+				go routines.MarkProjectHistory(*task.ProjectID, parsedUserID, 28, nil, nil, nil, nil, &task.ID, "")
+			}
+
 			// if reqBody.IsCompleted{
 			// 	go MarkSubTasksCompleted(task.ID)
 			// }
 
 		case "subtask":
 			var task models.SubTask
-			if err := initializers.DB.Preload("Users").First(&task, "id = ?", taskID).Error; err != nil {
+			if err := initializers.DB.Preload("Users").Preload("Task").First(&task, "id = ?", taskID).Error; err != nil {
 				if err == gorm.ErrRecordNotFound {
 					return &fiber.Error{Code: 400, Message: "No Sub Task of this ID found."}
 				}
@@ -369,12 +386,18 @@ func MarkTaskCompleted(taskType string) func(c *fiber.Ctx) error {
 				return &fiber.Error{Code: 403, Message: "Cannot Perform this action"}
 			}
 
+			completed := reqBody.IsCompleted && !task.IsCompleted
 			task.IsCompleted = reqBody.IsCompleted
 
-			result := initializers.DB.Save(&task)
+			result := initializers.DB.Omit("Task").Save(&task)
 			if result.Error != nil {
 				return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: result.Error.Error(), Err: result.Error}
 			}
+
+			if completed && task.Task.ProjectID != nil {
+				parsedUserID, _ := uuid.Parse(userID)
+				go routines.MarkProjectHistory(*task.Task.ProjectID, parsedUserID, 29, nil, nil, nil, nil, &task.TaskID, task.Title)
+			}
 		}
 
 		return c.Status(200).JSON(fiber.Map{
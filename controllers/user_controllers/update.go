@@ -265,3 +265,47 @@ func UpdateResume(c *fiber.Ctx) error {
 		"resume":  resumePath,
 	})
 }
+
+func GetPrivacySettings(c *fiber.Ctx) error {
+	userID := c.GetRespHeader("loggedInUserID")
+
+	var user models.User
+	if err := initializers.DB.Select("id", "allow_mentions_from").First(&user, "id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return &fiber.Error{Code: 400, Message: "No user of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":            "success",
+		"allowMentionsFrom": user.AllowMentionsFrom,
+	})
+}
+
+func UpdatePrivacySettings(c *fiber.Ctx) error {
+	userID := c.GetRespHeader("loggedInUserID")
+
+	var reqBody schemas.PrivacySettingsUpdateSchema
+	if err := c.BodyParser(&reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: "Invalid Request Body."}
+	}
+
+	if err := helpers.Validate[schemas.PrivacySettingsUpdateSchema](reqBody); err != nil {
+		return &fiber.Error{Code: 400, Message: err.Error()}
+	}
+
+	result := initializers.DB.Model(&models.User{}).Where("id = ?", userID).Update("allow_mentions_from", reqBody.AllowMentionsFrom)
+	if result.Error != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: result.Error.Error(), Err: result.Error}
+	}
+	if result.RowsAffected == 0 {
+		return &fiber.Error{Code: 400, Message: "No user of this ID found."}
+	}
+
+	return c.Status(200).JSON(fiber.Map{
+		"status":            "success",
+		"message":           "Privacy settings updated successfully",
+		"allowMentionsFrom": reqBody.AllowMentionsFrom,
+	})
+}
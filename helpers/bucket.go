@@ -28,6 +28,7 @@ var ChatClient *BucketClient
 var UserProfileClient *BucketClient
 var UserCoverClient *BucketClient
 var UserResumeBucket *BucketClient
+var ProjectFileClient *BucketClient
 
 func createNewBucketClient(uploadPath string) *BucketClient {
 	os.Setenv("GOOGLE_APPLICATION_CREDENTIALS", "bucket-key.json") // FILE PATH
@@ -52,6 +53,7 @@ func InitializeBucketClients() {
 	UserProfileClient = createNewBucketClient("users/profilePics/")
 	UserCoverClient = createNewBucketClient("users/coverPics/")
 	UserResumeBucket = createNewBucketClient("users/resumes/")
+	ProjectFileClient = createNewBucketClient("projects/files/")
 }
 
 func (c *BucketClient) UploadBucketFile(buffer *bytes.Buffer, object string) error {
@@ -85,3 +87,17 @@ func (c *BucketClient) DeleteBucketFile(fileName string) error {
 
 	return nil
 }
+
+// SignedURL gives a link to download a private file, valid for the expiry.
+func (c *BucketClient) SignedURL(fileName string, expiry time.Duration) (string, error) {
+	url, err := c.cl.Bucket(c.bucketName).SignedURL(c.uploadPath+fileName, &storage.SignedURLOptions{
+		Method:  "GET",
+		Expires: time.Now().Add(expiry),
+		Scheme:  storage.SigningSchemeV4,
+	})
+	if err != nil {
+		return "", fmt.Errorf("SignedURL: %v", err)
+	}
+
+	return url, nil
+}
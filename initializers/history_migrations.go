@@ -0,0 +1,17 @@
+package initializers
+
+import "fmt"
+
+// migrateHistoryConstraints drops the constraints which deleted the project history of a task or a milestone along with it,
+// so that AutoMigrate creates them again setting the task or milestone of the history to null instead.
+func migrateHistoryConstraints() {
+	for _, constraint := range []string{"fk_tasks_project_histories", "fk_milestones_project_histories"} {
+		if err := DB.Exec(fmt.Sprintf(`DO $$ BEGIN
+			IF EXISTS (SELECT 1 FROM pg_constraint WHERE conname = '%[1]s' AND confdeltype = 'c') THEN
+				ALTER TABLE project_histories DROP CONSTRAINT %[1]s;
+			END IF;
+		END $$`, constraint)).Error; err != nil {
+			fmt.Println("Error while migrating history constraints: ", err)
+		}
+	}
+}
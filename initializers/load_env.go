@@ -40,6 +40,8 @@ type Config struct {
 	GCP_PROJECT          string      `mapstructure:"GCP_PROJECT"`
 	GCP_BUCKET           string      `mapstructure:"GCP_BUCKET"`
 	POPULATE_DUMMIES     bool        `mapstructure:"POPULATE_DUMMIES"`
+
+	RECOMMENDATION_ENGINE string `mapstructure:"RECOMMENDATION_ENGINE" optional:"true"` //* ml (default) or local
 }
 
 var CONFIG Config
@@ -69,6 +71,11 @@ func LoadEnv() {
 		err := fmt.Errorf("invalid ENV value: %s", CONFIG.ENV)
 		log.Fatal(err)
 	}
+
+	if CONFIG.RECOMMENDATION_ENGINE != "" && CONFIG.RECOMMENDATION_ENGINE != "ml" && CONFIG.RECOMMENDATION_ENGINE != "local" {
+		err := fmt.Errorf("invalid RECOMMENDATION_ENGINE value: %s", CONFIG.RECOMMENDATION_ENGINE)
+		log.Fatal(err)
+	}
 }
 
 func getRequiredKeys(config Config) []string {
@@ -78,7 +85,7 @@ func getRequiredKeys(config Config) []string {
 	for i := 0; i < configType.NumField(); i++ {
 		field := configType.Field(i)
 		tag := field.Tag.Get("mapstructure")
-		if tag != "" {
+		if tag != "" && field.Tag.Get("optional") != "true" {
 			requiredKeys = append(requiredKeys, tag)
 		}
 	}
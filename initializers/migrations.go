@@ -8,6 +8,7 @@ import (
 
 func AutoMigrate() {
 	fmt.Println("\nStarting Migrations...")
+	migrateHistoryConstraints()
 	DB.AutoMigrate(
 		&models.User{},
 		&models.Profile{},
@@ -32,10 +33,34 @@ func AutoMigrate() {
 		&models.GroupChatMembership{},
 
 		&models.Post{},
+		&models.Hashtag{},
+		&models.HashtagFollow{},
+		&models.Poll{},
+		&models.PollOption{},
+		&models.PollVote{},
+		&models.Revision{},
+		&models.LinkPreview{},
 
 		&models.Project{},
 		&models.ProjectView{},
 		&models.ProjectHistory{},
+		&models.ProjectOwnershipTransfer{},
+		&models.Milestone{},
+		&models.ProjectFolder{},
+		&models.ProjectFile{},
+		&models.ProjectFileVersion{},
+		&models.WikiPage{},
+		&models.WikiRevision{},
+		&models.ProjectRoleDefinition{},
+		&models.ProjectTemplate{},
+		&models.ProjectTemplateOpening{},
+		&models.ProjectTemplateTask{},
+		&models.ProjectTemplateSubTask{},
+		&models.ProjectTemplateChat{},
+		&models.ProjectJoinRequest{},
+		&models.ProjectInviteLink{},
+		&models.ProjectDailyStat{},
+		&models.ProjectViewer{},
 		&models.Task{},
 		&models.SubTask{},
 		&models.Opening{},
@@ -45,6 +70,7 @@ func AutoMigrate() {
 		&models.Comment{},
 		&models.Invitation{},
 		&models.Like{},
+		&models.Mention{},
 
 		&models.LastViewedProjects{},
 		&models.LastViewedOpenings{},
@@ -61,7 +87,11 @@ func AutoMigrate() {
 		&models.Report{},
 		&models.Notification{},
 		&models.SearchQuery{},
+		&models.SearchQueryRollup{},
 		&models.Feedback{},
 	)
+	migrateSearch()
+	migrateDefaultProjectRoles()
+	seedProjectRoles()
 	fmt.Println("Migrations Finished!")
 }
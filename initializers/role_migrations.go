@@ -0,0 +1,37 @@
+package initializers
+
+import (
+	"fmt"
+
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/lib/pq"
+)
+
+// seedProjectRoles gives the default roles to the projects created before roles could be defined, new projects get them on creation.
+func seedProjectRoles() {
+	for name, permissions := range models.DefaultProjectRoles {
+		if err := DB.Exec(`INSERT INTO project_role_definitions (project_id, name, permissions, is_default)
+			SELECT id, ?, ?, true FROM projects
+			ON CONFLICT (project_id, name) DO NOTHING`, name, pq.StringArray(permissions)).Error; err != nil {
+			fmt.Println("Error while seeding project roles: ", err)
+		}
+	}
+}
+
+// oldDefaultProjectRoles are the default permissions the roles were first seeded with,
+// when editors could also create and delete openings and delete documents.
+var oldDefaultProjectRoles = map[models.ProjectRole][]string{
+	models.ProjectEditor:  {"openings", "chats", "settings", "documents"},
+	models.ProjectManager: {"tasks", "openings", "applications", "members", "chats", "settings", "documents"},
+}
+
+// migrateDefaultProjectRoles moves the default roles still on their first permissions to the current ones,
+// roles whose permissions were changed by the project are left as they are.
+func migrateDefaultProjectRoles() {
+	for name, permissions := range oldDefaultProjectRoles {
+		if err := DB.Exec(`UPDATE project_role_definitions SET permissions = ? WHERE is_default = true AND name = ? AND permissions = ?`,
+			pq.StringArray(models.DefaultProjectRoles[name]), name, pq.StringArray(permissions)).Error; err != nil {
+			fmt.Println("Error while migrating project roles: ", err)
+		}
+	}
+}
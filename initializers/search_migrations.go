@@ -0,0 +1,60 @@
+package initializers
+
+import (
+	"fmt"
+)
+
+// searchVectors are the weighted full text documents of the searchable tables, A is the weight of titles and names,
+// B of tags and categories and C of descriptions. The columns are generated, so Postgres keeps them up to date.
+var searchVectors = map[string]string{
+	"users": `setweight(to_tsvector('english', coalesce(name, '') || ' ' || coalesce(username, '')), 'A') ||
+		setweight(to_tsvector('english', immutable_array_to_string(tags)), 'B') ||
+		setweight(to_tsvector('english', coalesce(title, '') || ' ' || coalesce(tagline, '') || ' ' || coalesce(bio, '')), 'C')`,
+	"projects": `setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('english', immutable_array_to_string(tags) || ' ' || coalesce(category, '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(tagline, '') || ' ' || coalesce(description, '')), 'C')`,
+	"posts": `setweight(to_tsvector('english', immutable_array_to_string(tags)), 'B') ||
+		setweight(to_tsvector('english', coalesce(content, '')), 'C')`,
+	"openings": `setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('english', immutable_array_to_string(tags)), 'B') ||
+		setweight(to_tsvector('english', coalesce(description, '')), 'C')`,
+	"events": `setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('english', immutable_array_to_string(tags) || ' ' || coalesce(category, '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(tagline, '') || ' ' || coalesce(description, '') || ' ' || coalesce(location, '')), 'C')`,
+	"organizations": `setweight(to_tsvector('english', coalesce(organization_title, '')), 'A')`,
+	"wiki_pages": `setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(content, '')), 'C')`,
+}
+
+// trigramColumns are matched with pg_trgm similarity, so that typos in names still find results.
+var trigramColumns = map[string][]string{
+	"users":         {"name", "username"},
+	"organizations": {"organization_title"},
+}
+
+func migrateSearch() {
+	statements := []string{
+		`CREATE EXTENSION IF NOT EXISTS pg_trgm`,
+		//* array_to_string is only stable, generated columns need immutable functions
+		`CREATE OR REPLACE FUNCTION immutable_array_to_string(text[]) RETURNS text LANGUAGE sql IMMUTABLE PARALLEL SAFE AS $$ SELECT coalesce(array_to_string($1, ' '), '') $$`,
+	}
+
+	for table, vector := range searchVectors {
+		statements = append(statements,
+			fmt.Sprintf(`ALTER TABLE %s ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (%s) STORED`, table, vector),
+			fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%s_search_vector ON %s USING GIN (search_vector)`, table, table),
+		)
+	}
+
+	for table, columns := range trigramColumns {
+		for _, column := range columns {
+			statements = append(statements, fmt.Sprintf(`CREATE INDEX IF NOT EXISTS idx_%s_%s_trgm ON %s USING GIN (%s gin_trgm_ops)`, table, column, table, column))
+		}
+	}
+
+	for _, statement := range statements {
+		if err := DB.Exec(statement).Error; err != nil {
+			fmt.Println("Error while migrating search: ", err)
+		}
+	}
+}
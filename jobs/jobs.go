@@ -0,0 +1,31 @@
+package jobs
+
+import (
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/routines"
+)
+
+func every(interval time.Duration, job func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		job()
+	}
+}
+
+// Start launches the background jobs, each in its own goroutine.
+func Start() {
+	go every(time.Minute, routines.PublishScheduledPosts)
+
+	go routines.SeedAutocomplete()
+	go every(6*time.Hour, routines.SeedAutocomplete)
+
+	go routines.SeedTrendingSearches()
+	go routines.RollupSearchQueries()
+	go every(24*time.Hour, routines.RollupSearchQueries)
+
+	go routines.BackfillProjectAnalytics()
+	go every(time.Hour, routines.RollupProjectAnalytics)
+}
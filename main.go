@@ -4,6 +4,7 @@ import (
 	"github.com/Pratham-Mishra04/interact/config"
 	"github.com/Pratham-Mishra04/interact/helpers"
 	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/jobs"
 	"github.com/Pratham-Mishra04/interact/populate"
 	"github.com/Pratham-Mishra04/interact/routers"
 	"github.com/gofiber/fiber/v2"
@@ -50,5 +51,7 @@ func main() {
 
 	routers.Config(app)
 
+	jobs.Start()
+
 	app.Listen(":" + initializers.CONFIG.PORT)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/Pratham-Mishra04/interact/initializers"
 	"github.com/Pratham-Mishra04/interact/models"
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
 
@@ -22,16 +23,8 @@ func checkOrgAccess(UserRole models.OrganizationRole, AuthorizedRole models.Orga
 	return false
 }
 
-func checkProjectAccess(UserRole models.ProjectRole, AuthorizedRole models.ProjectRole) bool {
-	if UserRole == models.ProjectManager {
-		return true
-	} else if UserRole == models.ProjectEditor {
-		return AuthorizedRole != models.ProjectManager
-	} else if UserRole == models.ProjectMember {
-		return AuthorizedRole == models.ProjectMember
-	}
-
-	return false
+func checkProjectAccess(project *models.Project, UserRole models.ProjectRole, Permission models.ProjectPermission) bool {
+	return models.HasPermission(project.Roles, UserRole, Permission)
 }
 
 func OrgRoleAuthorization(Role models.OrganizationRole) func(*fiber.Ctx) error {
@@ -85,69 +78,130 @@ func OrgRoleAuthorization(Role models.OrganizationRole) func(*fiber.Ctx) error {
 	}
 }
 
-func ProjectRoleAuthorization(Role models.ProjectRole) func(*fiber.Ctx) error {
-	return func(c *fiber.Ctx) error {
-		loggedInUserID := c.GetRespHeader("loggedInUserID")
-		slug := c.Params("slug")
-		projectID := c.Params("projectID")
-		openingID := c.Params("openingID")
-		applicationID := c.Params("applicationID")
-		chatID := c.Params("chatID")
-		membershipID := c.Params("membershipID")
-		taskID := c.Params("taskID")
-
-		var project models.Project
-
-		projectInCache, err := cache.GetProject("-access--" + projectID)
-		if err == nil {
-			project = *projectInCache
-		} else {
-			if slug != "" {
-				if err := initializers.DB.Preload("Memberships").First(&project, "slug = ?", slug).Error; err != nil {
-					return &fiber.Error{Code: 400, Message: "Invalid Project."}
-				}
-			} else if projectID != "" {
-				if err := initializers.DB.Preload("Memberships").First(&project, "id = ?", projectID).Error; err != nil {
-					return &fiber.Error{Code: 400, Message: "Invalid Project."}
-				}
-			} else if openingID != "" {
-				var opening models.Opening
-				if err := initializers.DB.Preload("Project").Preload("Project.Memberships").First(&opening, "id = ?", openingID).Error; err != nil {
-					return &fiber.Error{Code: 400, Message: "Invalid Project."}
-				}
-				project = opening.Project
-			} else if applicationID != "" {
-				var application models.Application
-				if err := initializers.DB.Preload("Project").Preload("Project.Memberships").First(&application, "id = ?", applicationID).Error; err != nil {
-					return &fiber.Error{Code: 400, Message: "Invalid Project."}
-				}
-				project = application.Project
-			} else if chatID != "" {
-				var chat models.GroupChat
-				if err := initializers.DB.Preload("Project").Preload("Project.Memberships").First(&chat, "id = ?", chatID).Error; err != nil {
-					return &fiber.Error{Code: 400, Message: "Invalid Project."}
-				}
-				project = chat.Project
-			} else if membershipID != "" {
-				var membership models.Membership
-				if err := initializers.DB.Preload("Project").Preload("Project.Memberships").First(&membership, "id = ?", membershipID).Error; err != nil {
-					return &fiber.Error{Code: 400, Message: "Invalid Project."}
-				}
-				project = membership.Project
-			} else if taskID != "" {
-				var task models.Task
-				if err := initializers.DB.Preload("Project").Preload("Project.Memberships").First(&task, "id = ?", taskID).Error; err != nil {
-					var subTask models.SubTask
-					if err := initializers.DB.Preload("Task").Preload("Task.Project").Preload("Task.Project.Memberships").First(&subTask, "id = ?", taskID).Error; err != nil {
-						return &fiber.Error{Code: 400, Message: "Invalid Project."}
-					}
-					project = subTask.Task.Project
-				} else {
-					project = task.Project
+// getProjectFromParams finds the project of the slug, project, opening, application, chat, membership, task, milestone, folder, file, wiki page, join request or invite link in the params.
+func getProjectFromParams(c *fiber.Ctx) (*models.Project, error) {
+	slug := c.Params("slug")
+	projectID := c.Params("projectID")
+	openingID := c.Params("openingID")
+	applicationID := c.Params("applicationID")
+	chatID := c.Params("chatID")
+	membershipID := c.Params("membershipID")
+	taskID := c.Params("taskID")
+	milestoneID := c.Params("milestoneID")
+	folderID := c.Params("folderID")
+	fileID := c.Params("fileID")
+	pageID := c.Params("pageID")
+	joinRequestID := c.Params("joinRequestID")
+	inviteLinkID := c.Params("inviteLinkID")
+	messageID := c.Params("messageID")
+
+	var project models.Project
+
+	projectInCache, err := cache.GetProject("-access--" + projectID)
+	if err == nil {
+		project = *projectInCache
+	} else {
+		if slug != "" {
+			if err := initializers.DB.Preload("Memberships").Preload("Roles").First(&project, "slug = ?", slug).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
+			}
+		} else if projectID != "" {
+			if err := initializers.DB.Preload("Memberships").Preload("Roles").First(&project, "id = ?", projectID).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
+			}
+		} else if openingID != "" {
+			var opening models.Opening
+			if err := initializers.DB.Preload("Project").Preload("Project.Memberships").Preload("Project.Roles").First(&opening, "id = ?", openingID).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
+			}
+			project = opening.Project
+		} else if applicationID != "" {
+			var application models.Application
+			if err := initializers.DB.Preload("Project").Preload("Project.Memberships").Preload("Project.Roles").First(&application, "id = ?", applicationID).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
+			}
+			project = application.Project
+		} else if chatID != "" {
+			var chat models.GroupChat
+			if err := initializers.DB.Preload("Project").Preload("Project.Memberships").Preload("Project.Roles").First(&chat, "id = ?", chatID).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
+			}
+			project = chat.Project
+		} else if membershipID != "" {
+			var membership models.Membership
+			if err := initializers.DB.Preload("Project").Preload("Project.Memberships").Preload("Project.Roles").First(&membership, "id = ?", membershipID).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
+			}
+			project = membership.Project
+		} else if taskID != "" {
+			var task models.Task
+			if err := initializers.DB.Preload("Project").Preload("Project.Memberships").Preload("Project.Roles").First(&task, "id = ?", taskID).Error; err != nil {
+				var subTask models.SubTask
+				if err := initializers.DB.Preload("Task").Preload("Task.Project").Preload("Task.Project.Memberships").Preload("Task.Project.Roles").First(&subTask, "id = ?", taskID).Error; err != nil {
+					return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
 				}
+				project = subTask.Task.Project
+			} else {
+				project = task.Project
+			}
+		} else if milestoneID != "" {
+			var milestone models.Milestone
+			if err := initializers.DB.Preload("Project").Preload("Project.Memberships").Preload("Project.Roles").First(&milestone, "id = ?", milestoneID).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
+			}
+			project = milestone.Project
+		} else if folderID != "" {
+			var folder models.ProjectFolder
+			if err := initializers.DB.Preload("Project").Preload("Project.Memberships").Preload("Project.Roles").First(&folder, "id = ?", folderID).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
 			}
+			project = folder.Project
+		} else if fileID != "" {
+			var file models.ProjectFile
+			if err := initializers.DB.Preload("Project").Preload("Project.Memberships").Preload("Project.Roles").First(&file, "id = ?", fileID).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
+			}
+			project = file.Project
+		} else if pageID != "" {
+			var page models.WikiPage
+			if err := initializers.DB.Preload("Project").Preload("Project.Memberships").Preload("Project.Roles").First(&page, "id = ?", pageID).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
+			}
+			project = page.Project
+		} else if joinRequestID != "" {
+			var joinRequest models.ProjectJoinRequest
+			if err := initializers.DB.Preload("Project").Preload("Project.Memberships").Preload("Project.Roles").First(&joinRequest, "id = ?", joinRequestID).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
+			}
+			project = joinRequest.Project
+		} else if inviteLinkID != "" {
+			var inviteLink models.ProjectInviteLink
+			if err := initializers.DB.Preload("Project").Preload("Project.Memberships").Preload("Project.Roles").First(&inviteLink, "id = ?", inviteLinkID).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
+			}
+			project = inviteLink.Project
+		} else if messageID != "" {
+			var message models.GroupChatMessage
+			if err := initializers.DB.Preload("Chat.Project").Preload("Chat.Project.Memberships").Preload("Chat.Project.Roles").First(&message, "id = ?", messageID).Error; err != nil {
+				return nil, &fiber.Error{Code: 400, Message: "Invalid Project."}
+			}
+			project = message.Chat.Project
+		}
+
+		go cache.SetProject("-access--"+project.ID.String(), &project)
+	}
+
+	return &project, nil
+}
+
+// ProjectRoleAuthorization lets through the owner of the project and the members whose role has the permission.
+func ProjectRoleAuthorization(Permission models.ProjectPermission) func(*fiber.Ctx) error {
+	return func(c *fiber.Ctx) error {
+		loggedInUserID := c.GetRespHeader("loggedInUserID")
 
-			go cache.SetProject("-access--"+project.ID.String(), &project)
+		project, err := getProjectFromParams(c)
+		if err != nil {
+			return err
 		}
 
 		if project.UserID.String() == loggedInUserID {
@@ -160,7 +214,7 @@ func ProjectRoleAuthorization(Role models.ProjectRole) func(*fiber.Ctx) error {
 
 		for _, membership := range project.Memberships {
 			if membership.UserID.String() == loggedInUserID {
-				if !checkProjectAccess(membership.Role, Role) {
+				if !checkProjectAccess(project, membership.Role, Permission) {
 					return &fiber.Error{Code: 403, Message: "You don't have the Permission to perform this action."}
 				}
 				c.Set("projectMemberID", c.GetRespHeader("loggedInUserID"))
@@ -177,6 +231,35 @@ func ProjectRoleAuthorization(Role models.ProjectRole) func(*fiber.Ctx) error {
 	}
 }
 
+// OrgProjectAuthorization lets through the requests about the projects of the organization only. It runs after OrgRoleAuthorization,
+// which sets loggedInUserID to the user of the organization, the owner of all of its projects.
+func OrgProjectAuthorization(c *fiber.Ctx) error {
+	project, err := getProjectFromParams(c)
+	if err != nil {
+		return err
+	}
+
+	if project.ID == uuid.Nil || project.UserID.String() != c.GetRespHeader("loggedInUserID") {
+		return &fiber.Error{Code: 403, Message: "This project does not belong to this organization."}
+	}
+
+	return c.Next()
+}
+
+// ProjectNotArchived stops changes to an archived project, which stays read-only until it is unarchived.
+func ProjectNotArchived(c *fiber.Ctx) error {
+	project, err := getProjectFromParams(c)
+	if err != nil {
+		return c.Next() //* requests not about a project are left to the controller
+	}
+
+	if project.IsArchived {
+		return &fiber.Error{Code: 403, Message: "This project is archived."}
+	}
+
+	return c.Next()
+}
+
 func GroupChatAdminAuthorization() func(*fiber.Ctx) error {
 	return func(c *fiber.Ctx) error {
 		groupChatID := c.Params("chatID")
@@ -185,6 +268,7 @@ func GroupChatAdminAuthorization() func(*fiber.Ctx) error {
 		var chatMembership models.GroupChatMembership
 		err := initializers.DB.Preload("GroupChat.Project").
 			Preload("GroupChat.Project.Memberships").
+			Preload("GroupChat.Project.Roles").
 			Preload("GroupChat.Organization.Memberships").
 			First(&chatMembership, "group_chat_id = ? AND user_id = ?", groupChatID, loggedInUserID).Error
 		if err != nil {
@@ -198,7 +282,7 @@ func GroupChatAdminAuthorization() func(*fiber.Ctx) error {
 			roleMemberships := chatMembership.GroupChat.Project.Memberships
 			for _, membership := range roleMemberships {
 				if membership.UserID.String() == loggedInUserID &&
-					(membership.Role == models.ProjectEditor || membership.Role == models.ProjectManager) {
+					checkProjectAccess(&chatMembership.GroupChat.Project, membership.Role, models.ProjectChatsPermission) {
 					accessGranted = true
 					break
 				}
@@ -268,3 +352,22 @@ func SubTaskUsersAuthorization(c *fiber.Ctx) error {
 
 	return c.Next()
 }
+
+func AdminAuthorization(c *fiber.Ctx) error {
+	loggedInUserID := c.GetRespHeader("loggedInUserID")
+
+	//* not read from the user cache, Admin is not serialized
+	var user models.User
+	if err := initializers.DB.Select("id", "admin").First(&user, "id = ?", loggedInUserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 401, Message: "User of this token no longer exists"}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if !user.Admin {
+		return &fiber.Error{Code: 403, Message: "You don't have the Permission to perform this action."}
+	}
+
+	return c.Next()
+}
@@ -29,10 +29,11 @@ type ProjectBookmark struct {
 }
 
 type ProjectBookmarkItem struct {
-	ID                uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
-	ProjectBookmarkID uuid.UUID `gorm:"type:uuid;not null" json:"projectBookmarkID"`
-	ProjectID         uuid.UUID `gorm:"type:uuid;not null" json:"projectID"`
-	Project           Project   `json:"project"`
+	ID                uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	ProjectBookmarkID uuid.UUID  `gorm:"type:uuid;not null" json:"projectBookmarkID"`
+	ProjectID         uuid.UUID  `gorm:"type:uuid;not null" json:"projectID"`
+	Project           Project    `json:"project"`
+	CreatedAt         *time.Time `json:"createdAt"` //* null for items bookmarked before it was added, so they are left out of the analytics
 }
 
 type OpeningBookmark struct {
@@ -14,6 +14,10 @@ type Comment struct {
 	Project   Project    `gorm:"" json:"project"`
 	EventID   *uuid.UUID `gorm:"type:uuid" json:"eventID"`
 	Event     Event      `gorm:"" json:"event"`
+	ParentID  *uuid.UUID `gorm:"type:uuid;index" json:"parentID"`
+	Depth     int        `gorm:"default:0" json:"depth"` //* 0 for comments, n for replies n levels deep
+	NoReplies int        `gorm:"default:0" json:"noReplies"`
+	Replies   []Comment  `gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE" json:"-"`
 	UserID    uuid.UUID  `gorm:"type:uuid;not null" json:"userID"`
 	User      User       `gorm:"" json:"user"`
 	Content   string     `gorm:"type:text;not null" json:"content"`
@@ -22,4 +26,6 @@ type Comment struct {
 	CreatedAt time.Time  `gorm:"default:current_timestamp" json:"createdAt"`
 	UpdatedAt time.Time  `gorm:"default:current_timestamp" json:"updatedAt"`
 	Likes     []Like     `gorm:"foreignKey:CommentID;constraint:OnDelete:CASCADE" json:"-"`
+	Mentions  []Mention  `gorm:"foreignKey:CommentID;constraint:OnDelete:CASCADE" json:"mentions"`
+	Revisions []Revision `gorm:"foreignKey:CommentID;constraint:OnDelete:CASCADE" json:"-"`
 }
@@ -15,6 +15,7 @@ type Event struct {
 	BlurHash            string                `gorm:"type:text; default:no-hash" json:"blurHash"`
 	Description         string                `gorm:"type:text;not null" json:"description"`
 	Links               pq.StringArray        `gorm:"type:text[]" json:"links"`
+	LinkPreviews        []LinkPreview         `gorm:"foreignKey:EventID;constraint:OnDelete:CASCADE" json:"linkPreviews"`
 	Tags                pq.StringArray        `gorm:"type:text[]" json:"tags"`
 	NoViews             int                   `gorm:"default:0" json:"noViews"`
 	NoLikes             int                   `gorm:"default:0" json:"noLikes"`
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Hashtag struct {
+	ID          uuid.UUID       `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	Name        string          `gorm:"type:text;unique;not null" json:"name"` //* lowercase, without the leading #
+	NoPosts     int             `gorm:"default:0" json:"noPosts"`
+	NoFollowers int             `gorm:"default:0" json:"noFollowers"`
+	CreatedAt   time.Time       `gorm:"default:current_timestamp" json:"createdAt"`
+	Posts       []Post          `gorm:"many2many:post_hashtags;constraint:OnDelete:CASCADE" json:"-"`
+	Followers   []HashtagFollow `gorm:"foreignKey:HashtagID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type HashtagFollow struct {
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"userID"`
+	User      User      `gorm:"" json:"-"`
+	HashtagID uuid.UUID `gorm:"type:uuid;primaryKey" json:"hashtagID"`
+	Hashtag   Hashtag   `gorm:"" json:"hashtag"`
+	CreatedAt time.Time `gorm:"default:current_timestamp" json:"-"`
+}
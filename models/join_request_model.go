@@ -0,0 +1,35 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectJoinRequest struct {
+	ID          uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	ProjectID   uuid.UUID  `gorm:"type:uuid;not null;index" json:"projectID"`
+	Project     Project    `gorm:"" json:"project"`
+	UserID      uuid.UUID  `gorm:"type:uuid;not null;index" json:"userID"`
+	User        User       `gorm:"constraint:OnDelete:CASCADE" json:"user"`
+	Message     string     `gorm:"type:text" json:"message"`
+	Status      int        `gorm:"default:0" json:"status"` //* -1 for reject, 0 for waiting and, 1 for accept
+	DecidedByID *uuid.UUID `gorm:"type:uuid" json:"decidedByID"`
+	CreatedAt   time.Time  `gorm:"default:current_timestamp" json:"createdAt"`
+}
+
+// ProjectInviteLink adds whoever opens it to the project with its role and title, until it expires or runs out of uses.
+type ProjectInviteLink struct {
+	ID          uuid.UUID   `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	ProjectID   uuid.UUID   `gorm:"type:uuid;not null;index" json:"projectID"`
+	Project     Project     `gorm:"" json:"project"`
+	Code        string      `gorm:"type:text;not null;uniqueIndex" json:"code"`
+	Role        ProjectRole `gorm:"type:text;not null" json:"role"`
+	Title       string      `gorm:"type:varchar(25);not null" json:"title"`
+	MaxUses     int         `gorm:"default:0" json:"maxUses"` //* 0 for no limit
+	NoUses      int         `gorm:"default:0" json:"noUses"`
+	ExpiresAt   time.Time   `gorm:"not null" json:"expiresAt"`
+	CreatedByID uuid.UUID   `gorm:"type:uuid;not null" json:"createdByID"`
+	CreatedBy   User        `gorm:"foreignKey:CreatedByID;constraint:OnDelete:CASCADE" json:"createdBy"`
+	CreatedAt   time.Time   `gorm:"default:current_timestamp" json:"createdAt"`
+}
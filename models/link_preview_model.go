@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LinkPreview struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	URL                string     `gorm:"type:text;not null" json:"url"`
+	Title              string     `gorm:"type:varchar(200)" json:"title"`
+	Description        string     `gorm:"type:varchar(500)" json:"description"`
+	Image              string     `gorm:"type:text" json:"image"`
+	SiteName           string     `gorm:"type:varchar(200)" json:"siteName"`
+	PostID             *uuid.UUID `gorm:"type:uuid;index" json:"postID,omitempty"`
+	MessageID          *uuid.UUID `gorm:"type:uuid;index" json:"messageID,omitempty"`
+	GroupChatMessageID *uuid.UUID `gorm:"type:uuid;index" json:"groupChatMessageID,omitempty"`
+	ProjectID          *uuid.UUID `gorm:"type:uuid;index" json:"projectID,omitempty"`
+	EventID            *uuid.UUID `gorm:"type:uuid;index" json:"eventID,omitempty"`
+	CreatedAt          time.Time  `gorm:"default:current_timestamp" json:"-"`
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+/*
+Offset and Length are measured in characters (runes) of the
+parent's Content and cover the whole "@username" token.
+*/
+
+type Mention struct {
+	ID                 uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	UserID             uuid.UUID  `gorm:"type:uuid;not null" json:"userID"`
+	User               User       `gorm:"" json:"user"`
+	Username           string     `gorm:"type:text;not null" json:"username"`
+	Offset             int        `gorm:"not null" json:"offset"`
+	Length             int        `gorm:"not null" json:"length"`
+	PostID             *uuid.UUID `gorm:"type:uuid" json:"postID"`
+	CommentID          *uuid.UUID `gorm:"type:uuid" json:"commentID"`
+	MessageID          *uuid.UUID `gorm:"type:uuid" json:"messageID"`
+	GroupChatMessageID *uuid.UUID `gorm:"type:uuid" json:"groupChatMessageID"`
+	CreatedAt          time.Time  `gorm:"default:current_timestamp" json:"-"`
+}
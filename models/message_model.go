@@ -24,9 +24,11 @@ type Message struct {
 	Event     Event      `gorm:"" json:"event"`
 	// MessageID *uuid.UUID `gorm:"type:uuid" json:"messageID"` // replied message
 	// Message   Message    `json:"message"`
-	Content   string    `gorm:"type:text;not null" json:"content"`
-	CreatedAt time.Time `gorm:"default:current_timestamp;index:idx_created_at,sort:desc" json:"createdAt"`
-	Read      bool      `gorm:"default:false" json:"read"`
+	Content      string        `gorm:"type:text;not null" json:"content"`
+	CreatedAt    time.Time     `gorm:"default:current_timestamp;index:idx_created_at,sort:desc" json:"createdAt"`
+	Read         bool          `gorm:"default:false" json:"read"`
+	Mentions     []Mention     `gorm:"foreignKey:MessageID;constraint:OnDelete:CASCADE" json:"mentions"`
+	LinkPreviews []LinkPreview `gorm:"foreignKey:MessageID;constraint:OnDelete:CASCADE" json:"linkPreviews"`
 }
 
 type GroupChatMessage struct {
@@ -50,5 +52,7 @@ type GroupChatMessage struct {
 	// Message   Project    `json:"message"`
 	// Read      bool       `gorm:"default:false" json:"read"`
 	// ReadBy    []User     `gorm:"many2many:message_read_by;constraint:OnDelete:CASCADE" json:"readBy"`
-	CreatedAt time.Time `gorm:"default:current_timestamp" json:"createdAt"`
+	CreatedAt    time.Time     `gorm:"default:current_timestamp" json:"createdAt"`
+	Mentions     []Mention     `gorm:"foreignKey:GroupChatMessageID;constraint:OnDelete:CASCADE" json:"mentions"`
+	LinkPreviews []LinkPreview `gorm:"foreignKey:GroupChatMessageID;constraint:OnDelete:CASCADE" json:"linkPreviews"`
 }
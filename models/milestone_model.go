@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type MilestoneStatus string
+
+const (
+	MilestonePlanned    MilestoneStatus = "planned"
+	MilestoneInProgress MilestoneStatus = "in_progress"
+	MilestoneCompleted  MilestoneStatus = "completed"
+)
+
+type Milestone struct {
+	ID               uuid.UUID        `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	ProjectID        uuid.UUID        `gorm:"type:uuid;not null;index" json:"projectID"`
+	Project          Project          `gorm:"" json:"project"`
+	Title            string           `gorm:"type:text;not null" json:"title"`
+	Description      string           `gorm:"type:text" json:"description"`
+	DueDate          time.Time        `json:"dueDate"`
+	Status           MilestoneStatus  `gorm:"type:text;default:planned" json:"status"`
+	Tasks            []Task           `gorm:"foreignKey:MilestoneID;constraint:OnDelete:SET NULL" json:"tasks,omitempty"`
+	ProjectHistories []ProjectHistory `gorm:"foreignKey:MilestoneID;constraint:OnDelete:SET NULL" json:"-"` //* the activity of the project stays after the milestone is deleted
+	NoTasks          int              `gorm:"-" json:"noTasks"`
+	NoCompletedTasks int              `gorm:"-" json:"noCompletedTasks"`
+	Progress         float64          `gorm:"-" json:"progress"` //* fraction of the linked tasks which are completed
+	CompletedAt      *time.Time       `json:"completedAt"`
+	CreatedAt        time.Time        `gorm:"default:current_timestamp" json:"createdAt"`
+}
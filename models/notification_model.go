@@ -26,6 +26,18 @@ notification type:
 *14 - Your post got x impressions
 *15 - Your project got x impressions
 *16 - Your event got x impressions
+*17 - User mentioned you in a post
+*18 - User mentioned you in a comment
+*19 - User mentioned you in a message
+*20 - User reposted your post
+*21 - User quoted your post
+*22 - User replied to your comment
+*23 - User wants to transfer the ownership of a project to you
+*24 - User accepted the ownership of your project
+*25 - User completed a milestone of your project
+*26 - User requested to join your project
+*27 - User accepted your request to join the project
+*28 - User joined your project through an invite link
 */
 
 type Notification struct {
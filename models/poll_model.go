@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Poll struct {
+	ID             uuid.UUID    `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	PostID         uuid.UUID    `gorm:"type:uuid;unique;not null" json:"postID"`
+	IsMultiChoice  bool         `gorm:"default:false" json:"isMultiChoice"`
+	IsAnonymous    bool         `gorm:"default:false" json:"isAnonymous"`
+	EndsAt         *time.Time   `json:"endsAt"`
+	NoVoters       int          `gorm:"default:0" json:"noVoters"`
+	Options        []PollOption `gorm:"foreignKey:PollID;constraint:OnDelete:CASCADE" json:"options"`
+	Votes          []PollVote   `gorm:"foreignKey:PollID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt      time.Time    `gorm:"default:current_timestamp" json:"createdAt"`
+	ResultsHidden  bool         `gorm:"-" json:"resultsHidden"`
+	VotedOptionIDs []uuid.UUID  `gorm:"-" json:"votedOptionIDs"`
+}
+
+func (p *Poll) IsClosed() bool {
+	return p.EndsAt != nil && p.EndsAt.Before(time.Now())
+}
+
+type PollOption struct {
+	ID       uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	PollID   uuid.UUID  `gorm:"type:uuid;not null" json:"pollID"`
+	Content  string     `gorm:"type:varchar(50);not null" json:"content"`
+	Position int        `gorm:"not null" json:"position"`
+	NoVotes  int        `gorm:"default:0" json:"noVotes"`
+	Votes    []PollVote `gorm:"foreignKey:PollOptionID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+type PollVote struct {
+	ID            uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	PollID        uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_poll_vote_option;uniqueIndex:idx_poll_vote_single_choice,where:is_multi_choice = false" json:"pollID"`
+	PollOptionID  uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_poll_vote_option" json:"optionID"`
+	PollOption    PollOption `gorm:"" json:"-"`
+	UserID        uuid.UUID  `gorm:"type:uuid;not null;index;uniqueIndex:idx_poll_vote_option;uniqueIndex:idx_poll_vote_single_choice,where:is_multi_choice = false" json:"userID"`
+	User          User       `gorm:"" json:"user"`
+	IsMultiChoice bool       `gorm:"default:false" json:"-"` //* copied from the poll, so that a user can only have one vote in a single choice poll
+	CreatedAt     time.Time  `gorm:"default:current_timestamp" json:"createdAt"`
+}
@@ -7,6 +7,23 @@ import (
 	"github.com/lib/pq"
 )
 
+type PostStatus string
+
+const (
+	PostPublished PostStatus = "published"
+	PostDraft     PostStatus = "draft"
+	PostScheduled PostStatus = "scheduled"
+)
+
+type PostVisibility string
+
+const (
+	PostPublic     PostVisibility = "public"
+	PostFollowers  PostVisibility = "followers" //* followers of the author
+	PostMentioned  PostVisibility = "mentioned" //* users mentioned or tagged in the post
+	PostOrgMembers PostVisibility = "members"   //* members of the organization which made the post
+)
+
 type Post struct {
 	ID                  uuid.UUID             `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
 	UserID              uuid.UUID             `gorm:"type:uuid;not null" json:"userID"`
@@ -19,13 +36,24 @@ type Post struct {
 	NoLikes             int                   `gorm:"default:0" json:"noLikes"`
 	NoComments          int                   `gorm:"default:0" json:"noComments"`
 	RePostID            *uuid.UUID            `gorm:"type:uuid" json:"rePostID"`
-	RePost              *Post                 `gorm:"foreignKey:RePostID" json:"rePost"`
+	RePost              *Post                 `gorm:"foreignKey:RePostID;constraint:OnDelete:SET NULL" json:"rePost"`
+	RePostRemoved       bool                  `gorm:"default:false" json:"rePostRemoved"` //* the reposted post was deleted, shown as a stub
 	NoOfReposts         int                   `gorm:"default:0" json:"noReposts"`
 	Tags                pq.StringArray        `gorm:"type:text[]" json:"tags"`
 	Impressions         int                   `gorm:"default:0" json:"noImpressions"`
 	Edited              bool                  `gorm:"default:false" json:"edited"`
+	Status              PostStatus            `gorm:"type:text;default:published;index" json:"status"`
+	ScheduledFor        *time.Time            `gorm:"index" json:"scheduledFor"`
+	Visibility          PostVisibility        `gorm:"type:text;default:public;index" json:"visibility"`
+	OrganizationID      *uuid.UUID            `gorm:"type:uuid" json:"-"` //* set for posts made through an organization, to mark history at publish time
+	OrgMemberID         *uuid.UUID            `gorm:"type:uuid" json:"-"`
 	Comments            []Comment             `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE" json:"comments"`
 	TaggedUsers         []User                `gorm:"many2many:post_tagged_users" json:"taggedUsers"`
+	Mentions            []Mention             `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE" json:"mentions"`
+	LinkPreviews        []LinkPreview         `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE" json:"linkPreviews"`
+	Hashtags            []Hashtag             `gorm:"many2many:post_hashtags;constraint:OnDelete:CASCADE" json:"hashtags"`
+	Poll                *Poll                 `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE" json:"poll"`
+	Revisions           []Revision            `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE" json:"-"`
 	Notifications       []Notification        `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE" json:"-"`
 	Messages            []Message             `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE" json:"-"`
 	GroupChatMessages   []GroupChatMessage    `gorm:"foreignKey:PostID;constraint:OnDelete:CASCADE" json:"-"`
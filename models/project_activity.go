@@ -0,0 +1,102 @@
+package models
+
+import "fmt"
+
+// activityObject is what a history entry is about, other than the sender.
+type activityObject int
+
+const (
+	activityNone activityObject = iota
+	activityUser
+	activityOpening
+	activityTask
+	activityMilestone
+	activityText //* the name kept in deleted text, for things which are gone or have no model
+)
+
+type projectActivityType struct {
+	Type    string
+	Summary string //* format with the name of the sender, then of the object if there is one
+	Object  activityObject
+}
+
+var projectActivityTypes = map[int]projectActivityType{
+	-1: {"project_created", "%s created this project", activityNone},
+	0:  {"member_invited", "%s invited %s to the project", activityUser},
+	1:  {"member_joined", "%s joined this project", activityNone},
+	2:  {"project_edited", "%s edited the project details", activityNone},
+	3:  {"opening_created", "%s created the opening %s", activityOpening},
+	4:  {"opening_edited", "%s edited the opening %s", activityOpening},
+	5:  {"opening_deleted", "%s deleted the opening %s", activityText},
+	6:  {"application_accepted", "%s accepted the application of %s", activityUser},
+	7:  {"application_rejected", "%s rejected the application of %s", activityUser},
+	8:  {"chat_created", "%s created a new group chat", activityNone},
+	9:  {"task_created", "%s created the task %s", activityTask},
+	10: {"member_left", "%s left the project", activityNone},
+	11: {"member_removed", "%s removed %s from the project", activityUser},
+	12: {"ownership_transferred", "%s transferred the ownership of this project to %s", activityUser},
+	13: {"project_archived", "%s archived this project", activityNone},
+	14: {"project_unarchived", "%s unarchived this project", activityNone},
+	15: {"milestone_created", "%s created the milestone %s", activityMilestone},
+	16: {"milestone_completed", "%s completed the milestone %s", activityMilestone},
+	17: {"file_uploaded", "%s uploaded the file %s", activityText},
+	18: {"file_version_uploaded", "%s uploaded a new version of the file %s", activityText},
+	19: {"file_deleted", "%s deleted the file %s", activityText},
+	20: {"folder_created", "%s created the folder %s", activityText},
+	21: {"folder_deleted", "%s deleted the folder %s", activityText},
+	22: {"wiki_page_created", "%s created the wiki page %s", activityText},
+	23: {"wiki_page_deleted", "%s deleted the wiki page %s", activityText},
+	24: {"join_request_approved", "%s approved the join request of %s", activityUser},
+	25: {"join_request_denied", "%s denied the join request of %s", activityUser},
+	26: {"invite_link_created", "%s created an invite link for %s", activityText},
+	27: {"member_joined_by_link", "%s joined this project through an invite link", activityNone},
+	28: {"task_completed", "%s completed the task %s", activityTask},
+	29: {"subtask_completed", "%s completed the subtask %s", activityText},
+	30: {"milestone_updated", "%s updated the milestone %s", activityMilestone},
+	31: {"milestone_deleted", "%s deleted the milestone %s", activityText},
+}
+
+// ProjectHistoryTypeFromName gives the history type code of an activity type, like "task_completed".
+func ProjectHistoryTypeFromName(name string) (int, bool) {
+	for historyType, activityType := range projectActivityTypes {
+		if activityType.Type == name {
+			return historyType, true
+		}
+	}
+	return 0, false
+}
+
+func nameOr(name string, fallback string) string {
+	if name == "" {
+		return fallback
+	}
+	return name
+}
+
+// SetActivity fills the activity type and the readable summary of the history, from its preloaded sender and object.
+func (history *ProjectHistory) SetActivity() {
+	activityType, ok := projectActivityTypes[history.HistoryType]
+	if !ok {
+		history.Type = "unknown"
+		return
+	}
+
+	history.Type = activityType.Type
+
+	sender := nameOr(history.Sender.Name, "Someone")
+
+	switch activityType.Object {
+	case activityUser:
+		history.Summary = fmt.Sprintf(activityType.Summary, sender, nameOr(history.User.Name, "a user"))
+	case activityOpening:
+		history.Summary = fmt.Sprintf(activityType.Summary, sender, nameOr(history.Opening.Title, "an opening"))
+	case activityTask:
+		history.Summary = fmt.Sprintf(activityType.Summary, sender, nameOr(history.Task.Title, "a task"))
+	case activityMilestone:
+		history.Summary = fmt.Sprintf(activityType.Summary, sender, nameOr(history.Milestone.Title, "a milestone"))
+	case activityText:
+		history.Summary = fmt.Sprintf(activityType.Summary, sender, nameOr(history.DeletedText, "an item"))
+	default:
+		history.Summary = fmt.Sprintf(activityType.Summary, sender)
+	}
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectAnalyticsMetrics struct {
+	Views                int `gorm:"default:0" json:"views"`
+	UniqueViewers        int `gorm:"-" json:"uniqueViewers"` //* distinct viewers do not add up across days, so they are counted from ProjectViewer instead
+	Impressions          int `gorm:"default:0" json:"impressions"`
+	Likes                int `gorm:"default:0" json:"likes"`
+	Comments             int `gorm:"default:0" json:"comments"`
+	Shares               int `gorm:"default:0" json:"shares"`
+	Bookmarks            int `gorm:"default:0" json:"bookmarks"`
+	Applications         int `gorm:"default:0" json:"applications"`
+	AcceptedApplications int `gorm:"default:0" json:"acceptedApplications"`
+}
+
+func (metrics *ProjectAnalyticsMetrics) Add(other ProjectAnalyticsMetrics) {
+	metrics.Views += other.Views
+	metrics.UniqueViewers += other.UniqueViewers
+	metrics.Impressions += other.Impressions
+	metrics.Likes += other.Likes
+	metrics.Comments += other.Comments
+	metrics.Shares += other.Shares
+	metrics.Bookmarks += other.Bookmarks
+	metrics.Applications += other.Applications
+	metrics.AcceptedApplications += other.AcceptedApplications
+}
+
+// ProjectDailyStat is the rollup of the analytics of a project for a day (UTC), kept up to date by the RollupProjectAnalytics job.
+type ProjectDailyStat struct {
+	ProjectID               uuid.UUID `gorm:"type:uuid;primaryKey" json:"projectID"`
+	Date                    time.Time `gorm:"type:date;primaryKey" json:"date"`
+	ProjectAnalyticsMetrics `gorm:"embedded"`
+}
+
+// ProjectViewer is a logged in user who viewed a project on a day (UTC).
+type ProjectViewer struct {
+	ProjectID uuid.UUID `gorm:"type:uuid;primaryKey" json:"projectID"`
+	Date      time.Time `gorm:"type:date;primaryKey" json:"date"`
+	UserID    uuid.UUID `gorm:"type:uuid;primaryKey" json:"userID"`
+}
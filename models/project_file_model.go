@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ProjectFolder struct {
+	ID          uuid.UUID       `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	ProjectID   uuid.UUID       `gorm:"type:uuid;not null;index" json:"projectID"`
+	Project     Project         `gorm:"" json:"-"`
+	ParentID    *uuid.UUID      `gorm:"type:uuid;index" json:"parentID"` //* nil for folders at the root of the project
+	Name        string          `gorm:"type:text;not null" json:"name"`
+	CreatedByID uuid.UUID       `gorm:"type:uuid;not null" json:"createdByID"`
+	CreatedBy   User            `gorm:"foreignKey:CreatedByID" json:"createdBy"`
+	Folders     []ProjectFolder `gorm:"foreignKey:ParentID;constraint:OnDelete:CASCADE" json:"-"`
+	Files       []ProjectFile   `gorm:"foreignKey:FolderID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedAt   time.Time       `gorm:"default:current_timestamp" json:"createdAt"`
+}
+
+type ProjectFile struct {
+	ID            uuid.UUID            `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	ProjectID     uuid.UUID            `gorm:"type:uuid;not null;index" json:"projectID"`
+	Project       Project              `gorm:"" json:"-"`
+	FolderID      *uuid.UUID           `gorm:"type:uuid;index" json:"folderID"` //* nil for files at the root of the project
+	Name          string               `gorm:"type:text;not null" json:"name"`
+	MimeType      string               `gorm:"type:text" json:"mimeType"`
+	Size          int64                `gorm:"default:0" json:"size"` //* of the latest version
+	LatestVersion int                  `gorm:"default:1" json:"latestVersion"`
+	Versions      []ProjectFileVersion `gorm:"foreignKey:FileID;constraint:OnDelete:CASCADE" json:"versions,omitempty"`
+	UploadedByID  uuid.UUID            `gorm:"type:uuid;not null" json:"uploadedByID"`
+	UploadedBy    User                 `gorm:"foreignKey:UploadedByID" json:"uploadedBy"`
+	CreatedAt     time.Time            `gorm:"default:current_timestamp" json:"createdAt"`
+	UpdatedAt     time.Time            `gorm:"default:current_timestamp" json:"updatedAt"`
+}
+
+type ProjectFileVersion struct {
+	ID           uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	FileID       uuid.UUID `gorm:"type:uuid;not null;index" json:"fileID"`
+	Version      int       `gorm:"not null" json:"version"`
+	Path         string    `gorm:"type:text;not null" json:"-"`
+	Size         int64     `gorm:"not null" json:"size"`
+	MimeType     string    `gorm:"type:text" json:"mimeType"`
+	UploadedByID uuid.UUID `gorm:"type:uuid;not null" json:"uploadedByID"`
+	UploadedBy   User      `gorm:"foreignKey:UploadedByID" json:"uploadedBy"`
+	CreatedAt    time.Time `gorm:"default:current_timestamp" json:"createdAt"`
+}
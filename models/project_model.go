@@ -5,49 +5,70 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/lib/pq"
+	"gorm.io/gorm"
 )
 
 type Project struct {
-	ID                  uuid.UUID             `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
-	Title               string                `gorm:"type:text;not null" json:"title"` //TODO Validation error handling for no of chars
-	Slug                string                `gorm:"type:text;not null" json:"slug"`
-	Tagline             string                `gorm:"type:text;not null" json:"tagline"`
-	CoverPic            string                `gorm:"type:text; default:default.jpg" json:"coverPic"`
-	BlurHash            string                `gorm:"type:text; default:no-hash" json:"blurHash"`
-	Description         string                `gorm:"type:text;not null" json:"description"`
-	UserID              uuid.UUID             `gorm:"type:uuid;not null" json:"userID"`
-	User                User                  `gorm:"" json:"user"`
-	CreatedAt           time.Time             `gorm:"default:current_timestamp" json:"createdAt"`
-	Tags                pq.StringArray        `gorm:"type:text[]" json:"tags"`
-	NoLikes             int                   `gorm:"default:0" json:"noLikes"`
-	NoShares            int                   `gorm:"default:0" json:"noShares"`
-	NoComments          int                   `gorm:"default:0" json:"noComments"`
-	TotalNoViews        int                   `gorm:"default:0" json:"totalNoViews"`
-	Category            string                `gorm:"type:text;not null" json:"category"`
-	IsPrivate           bool                  `gorm:"default:false" json:"isPrivate"`
-	TRatio              int                   `json:"-"`
-	Views               int                   `json:"views"`
-	NumberOfMembers     int                   `gorm:"default:1" json:"noMembers"`
-	Impressions         int                   `gorm:"default:0" json:"noImpressions"`
-	Links               pq.StringArray        `gorm:"type:text[]" json:"links"`
-	PrivateLinks        pq.StringArray        `gorm:"type:text[]" json:"-"`
-	Comments            []Comment             `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"comments"`
-	Openings            []Opening             `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"openings"`
-	Chats               []GroupChat           `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"chats"`
-	Invitations         []Invitation          `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"invitations"`
-	Memberships         []Membership          `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"memberships"`
-	Tasks               []Task                `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"tasks"`
-	History             []ProjectHistory      `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
-	Notifications       []Notification        `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
-	LastViews           []LastViewedProjects  `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
-	Messages            []Message             `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
-	GroupChatMessages   []GroupChatMessage    `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
-	BookMarkItems       []ProjectBookmarkItem `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
-	ProjectViews        []ProjectView         `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
-	Likes               []Like                `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
-	Applications        []Application         `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
-	Reports             []Report              `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
-	OrganizationHistory []OrganizationHistory `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	ID                  uuid.UUID                  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	Title               string                     `gorm:"type:text;not null" json:"title"` //TODO Validation error handling for no of chars
+	Slug                string                     `gorm:"type:text;not null" json:"slug"`
+	Tagline             string                     `gorm:"type:text;not null" json:"tagline"`
+	CoverPic            string                     `gorm:"type:text; default:default.jpg" json:"coverPic"`
+	BlurHash            string                     `gorm:"type:text; default:no-hash" json:"blurHash"`
+	Description         string                     `gorm:"type:text;not null" json:"description"`
+	UserID              uuid.UUID                  `gorm:"type:uuid;not null" json:"userID"`
+	User                User                       `gorm:"" json:"user"`
+	CreatedAt           time.Time                  `gorm:"default:current_timestamp" json:"createdAt"`
+	Tags                pq.StringArray             `gorm:"type:text[]" json:"tags"`
+	NoLikes             int                        `gorm:"default:0" json:"noLikes"`
+	NoShares            int                        `gorm:"default:0" json:"noShares"`
+	NoComments          int                        `gorm:"default:0" json:"noComments"`
+	TotalNoViews        int                        `gorm:"default:0" json:"totalNoViews"`
+	Category            string                     `gorm:"type:text;not null" json:"category"`
+	IsPrivate           bool                       `gorm:"default:false" json:"isPrivate"`
+	IsArchived          bool                       `gorm:"default:false" json:"isArchived"` //* archived projects are read-only
+	ArchivedAt          *time.Time                 `json:"archivedAt"`
+	StorageUsed         int64                      `gorm:"default:0" json:"storageUsed"` //* bytes used by the files of the project
+	TRatio              int                        `json:"-"`
+	Views               int                        `json:"views"`
+	NumberOfMembers     int                        `gorm:"default:1" json:"noMembers"`
+	Impressions         int                        `gorm:"default:0" json:"noImpressions"`
+	Links               pq.StringArray             `gorm:"type:text[]" json:"links"`
+	PrivateLinks        pq.StringArray             `gorm:"type:text[]" json:"-"`
+	LinkPreviews        []LinkPreview              `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"linkPreviews"`
+	Comments            []Comment                  `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"comments"`
+	Openings            []Opening                  `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"openings"`
+	Chats               []GroupChat                `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"chats"`
+	Invitations         []Invitation               `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"invitations"`
+	Memberships         []Membership               `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"memberships"`
+	Roles               []ProjectRoleDefinition    `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"roles,omitempty"`
+	Tasks               []Task                     `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"tasks"`
+	History             []ProjectHistory           `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	Notifications       []Notification             `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	LastViews           []LastViewedProjects       `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	Messages            []Message                  `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	GroupChatMessages   []GroupChatMessage         `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	BookMarkItems       []ProjectBookmarkItem      `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	ProjectViews        []ProjectView              `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	Likes               []Like                     `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	Applications        []Application              `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	Reports             []Report                   `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	OrganizationHistory []OrganizationHistory      `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	Folders             []ProjectFolder            `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	Files               []ProjectFile              `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	WikiPages           []WikiPage                 `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	JoinRequests        []ProjectJoinRequest       `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	InviteLinks         []ProjectInviteLink        `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	DailyStats          []ProjectDailyStat         `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	Viewers             []ProjectViewer            `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	Milestones          []Milestone                `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+	OwnershipTransfers  []ProjectOwnershipTransfer `gorm:"foreignKey:ProjectID;constraint:OnDelete:CASCADE" json:"-"`
+}
+
+// AfterCreate seeds the default roles of the new project.
+func (project *Project) AfterCreate(tx *gorm.DB) error {
+	roles := NewDefaultProjectRoles(project.ID)
+	return tx.Create(&roles).Error
 }
 
 type ProjectView struct {
@@ -72,6 +93,26 @@ history type:
 *9 - User created a new task
 *10 - User left the project
 *11 - User removed user from the project
+*12 - User transferred the ownership of this project to user
+*13 - User archived this project
+*14 - User unarchived this project
+*15 - User created a milestone
+*16 - User completed a milestone
+*17 - User uploaded a file
+*18 - User uploaded a new version of a file
+*19 - User deleted a file
+*20 - User created a folder
+*21 - User deleted a folder
+*22 - User created a wiki page
+*23 - User deleted a wiki page
+*24 - User approved the join request of user
+*25 - User denied the join request of user
+*26 - User created an invite link
+*27 - User joined this project through an invite link
+*28 - User completed a task
+*29 - User completed a subtask
+*30 - User updated a milestone
+*31 - User deleted a milestone
 */
 
 type ProjectHistory struct {
@@ -90,6 +131,22 @@ type ProjectHistory struct {
 	Invitation    Invitation  `json:"invitation"`
 	TaskID        *uuid.UUID  `gorm:"type:uuid" json:"taskID"`
 	Task          Task        `json:"task"`
+	MilestoneID   *uuid.UUID  `gorm:"type:uuid" json:"milestoneID"`
+	Milestone     Milestone   `json:"milestone"`
 	DeletedText   string      `gorm:"type:text" json:"deletedText"`
+	Type          string      `gorm:"-" json:"type"`    //* name of the history type, like task_completed
+	Summary       string      `gorm:"-" json:"summary"` //* readable line for the activity feed
 	CreatedAt     time.Time   `gorm:"default:current_timestamp;index:idx_created_at,sort:desc" json:"createdAt"`
 }
+
+type ProjectOwnershipTransfer struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	ProjectID  uuid.UUID `gorm:"type:uuid;not null" json:"projectID"`
+	Project    Project   `gorm:"" json:"project"`
+	FromUserID uuid.UUID `gorm:"type:uuid;not null" json:"fromUserID"`
+	FromUser   User      `gorm:"foreignKey:FromUserID;constraint:OnDelete:CASCADE" json:"fromUser"`
+	ToUserID   uuid.UUID `gorm:"type:uuid;not null" json:"toUserID"`
+	ToUser     User      `gorm:"foreignKey:ToUserID;constraint:OnDelete:CASCADE" json:"toUser"`
+	Status     int       `gorm:"default:0" json:"status"` //* -1 for reject, 0 for waiting and, 1 for accept
+	CreatedAt  time.Time `gorm:"default:current_timestamp" json:"createdAt"`
+}
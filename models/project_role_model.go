@@ -0,0 +1,95 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type ProjectPermission string
+
+const (
+	ProjectViewPermission            ProjectPermission = "view" //* every member of the project has it
+	ProjectTasksPermission           ProjectPermission = "tasks"
+	ProjectOpeningsPermission        ProjectPermission = "openings" //* create and delete openings
+	ProjectEditOpeningsPermission    ProjectPermission = "edit_openings"
+	ProjectApplicationsPermission    ProjectPermission = "applications"
+	ProjectMembersPermission         ProjectPermission = "members"
+	ProjectChatsPermission           ProjectPermission = "chats"
+	ProjectSettingsPermission        ProjectPermission = "settings"
+	ProjectDocumentsPermission       ProjectPermission = "documents" //* add and edit files and wiki pages
+	ProjectDeleteDocumentsPermission ProjectPermission = "delete_documents"
+)
+
+// DefaultProjectRoles are seeded in every project, they can be given other permissions but not renamed or deleted.
+// They keep the rights of the old role ladder: editors edit openings and documents, only managers create or delete them.
+var DefaultProjectRoles = map[ProjectRole][]string{
+	ProjectMember: {},
+	ProjectEditor: {
+		string(ProjectEditOpeningsPermission),
+		string(ProjectChatsPermission),
+		string(ProjectSettingsPermission),
+		string(ProjectDocumentsPermission),
+	},
+	ProjectManager: {
+		string(ProjectTasksPermission),
+		string(ProjectOpeningsPermission),
+		string(ProjectEditOpeningsPermission),
+		string(ProjectApplicationsPermission),
+		string(ProjectMembersPermission),
+		string(ProjectChatsPermission),
+		string(ProjectSettingsPermission),
+		string(ProjectDocumentsPermission),
+		string(ProjectDeleteDocumentsPermission),
+	},
+}
+
+type ProjectRoleDefinition struct {
+	ID          uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	ProjectID   uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_project_role_name" json:"projectID"`
+	Name        ProjectRole    `gorm:"type:text;not null;uniqueIndex:idx_project_role_name" json:"name"`
+	Permissions pq.StringArray `gorm:"type:text[]" json:"permissions"`
+	IsDefault   bool           `gorm:"default:false" json:"isDefault"`
+	CreatedAt   time.Time      `gorm:"default:current_timestamp" json:"createdAt"`
+}
+
+// HasPermission tells if the role of the name grants the permission in the project of the roles,
+// roles without a definition fall back to the default ones.
+func HasPermission(roles []ProjectRoleDefinition, name ProjectRole, permission ProjectPermission) bool {
+	if permission == ProjectViewPermission {
+		return true
+	}
+
+	permissions, found := DefaultProjectRoles[name]
+	for _, role := range roles {
+		if role.Name == name {
+			permissions, found = role.Permissions, true
+			break
+		}
+	}
+	if !found {
+		return false
+	}
+
+	for _, p := range permissions {
+		if p == string(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewDefaultProjectRoles gives the definitions of the default roles for a new project.
+func NewDefaultProjectRoles(projectID uuid.UUID) []ProjectRoleDefinition {
+	roles := make([]ProjectRoleDefinition, 0, len(DefaultProjectRoles))
+	for _, name := range []ProjectRole{ProjectMember, ProjectEditor, ProjectManager} {
+		roles = append(roles, ProjectRoleDefinition{
+			ProjectID:   projectID,
+			Name:        name,
+			Permissions: DefaultProjectRoles[name],
+			IsDefault:   true,
+		})
+	}
+	return roles
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// ProjectTemplate is the skeleton of a project saved by its owner, to create near identical projects from.
+// Templates of an organization make its library, open to all of its members.
+type ProjectTemplate struct {
+	ID              uuid.UUID                `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	Name            string                   `gorm:"type:text;not null" json:"name"`
+	About           string                   `gorm:"type:text" json:"about"` //* what the template is for
+	UserID          uuid.UUID                `gorm:"type:uuid;not null;index" json:"userID"`
+	User            User                     `gorm:"constraint:OnDelete:CASCADE" json:"user"`
+	OrganizationID  *uuid.UUID               `gorm:"type:uuid;index" json:"orgID"`
+	Organization    *Organization            `gorm:"constraint:OnDelete:CASCADE" json:"organization,omitempty"`
+	SourceProjectID *uuid.UUID               `gorm:"type:uuid" json:"sourceProjectID"`
+	SourceProject   *Project                 `gorm:"foreignKey:SourceProjectID;constraint:OnDelete:SET NULL" json:"-"`
+	Description     string                   `gorm:"type:text" json:"description"`
+	Tags            pq.StringArray           `gorm:"type:text[]" json:"tags"`
+	Category        string                   `gorm:"type:text" json:"category"`
+	Links           pq.StringArray           `gorm:"type:text[]" json:"links"`
+	Openings        []ProjectTemplateOpening `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE" json:"openings,omitempty"`
+	Tasks           []ProjectTemplateTask    `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE" json:"tasks,omitempty"`
+	Chats           []ProjectTemplateChat    `gorm:"foreignKey:TemplateID;constraint:OnDelete:CASCADE" json:"chats,omitempty"`
+	NoUses          int                      `gorm:"default:0" json:"noUses"`
+	CreatedAt       time.Time                `gorm:"default:current_timestamp" json:"createdAt"`
+}
+
+type ProjectTemplateOpening struct {
+	ID          uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	TemplateID  uuid.UUID      `gorm:"type:uuid;not null;index" json:"templateID"`
+	Title       string         `gorm:"type:text;not null" json:"title"`
+	Description string         `gorm:"type:text;not null" json:"description"`
+	Tags        pq.StringArray `gorm:"type:text[]" json:"tags"`
+	Active      bool           `gorm:"default:true" json:"active"`
+}
+
+type ProjectTemplateTask struct {
+	ID           uuid.UUID                `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	TemplateID   uuid.UUID                `gorm:"type:uuid;not null;index" json:"templateID"`
+	Title        string                   `gorm:"type:text;not null" json:"title"`
+	Description  string                   `gorm:"type:text" json:"description"`
+	Tags         pq.StringArray           `gorm:"type:text[]" json:"tags"`
+	Priority     Priority                 `gorm:"type:text;default:low" json:"priority"`
+	DeadlineDays int                      `gorm:"default:0" json:"deadlineDays"` //* days after the creation of the project
+	SubTasks     []ProjectTemplateSubTask `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"subTasks"`
+}
+
+type ProjectTemplateSubTask struct {
+	ID           uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	TaskID       uuid.UUID      `gorm:"type:uuid;not null;index" json:"taskID"`
+	Title        string         `gorm:"type:text;not null" json:"title"`
+	Description  string         `gorm:"type:text" json:"description"`
+	Tags         pq.StringArray `gorm:"type:text[]" json:"tags"`
+	Priority     Priority       `gorm:"type:text;default:low" json:"priority"`
+	DeadlineDays int            `gorm:"default:0" json:"deadlineDays"`
+}
+
+type ProjectTemplateChat struct {
+	ID          uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	TemplateID  uuid.UUID `gorm:"type:uuid;not null;index" json:"templateID"`
+	Title       string    `gorm:"type:varchar(50)" json:"title"`
+	Description string    `gorm:"type:text" json:"description"`
+	AdminOnly   bool      `gorm:"default:false" json:"adminOnly"`
+}
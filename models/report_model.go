@@ -15,6 +15,8 @@ type Report struct { //TODO notifications will be sent after reporting
 	User        User       `json:"user"`
 	PostID      *uuid.UUID `gorm:"type:uuid" json:"postID"`
 	Post        Post       `json:"post"`
+	CommentID   *uuid.UUID `gorm:"type:uuid" json:"commentID"`
+	Comment     Comment    `json:"comment"`
 	ProjectID   *uuid.UUID `gorm:"type:uuid" json:"projectID"`
 	Project     Project    `json:"project"`
 	EventID     *uuid.UUID `gorm:"type:uuid" json:"eventID"`
@@ -25,4 +27,6 @@ type Report struct { //TODO notifications will be sent after reporting
 	GroupChat   GroupChat  `json:"chat"`
 	Content     string     `json:"content"`
 	CreatedAt   time.Time  `gorm:"default:current_timestamp" json:"createdAt"`
+	//* text of the reported post or comment as first written, set for report review
+	OriginalContent string `gorm:"-" json:"originalContent,omitempty"`
 }
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Revision is an earlier version of the content of a post or a comment, saved each time it is edited.
+type Revision struct {
+	ID        uuid.UUID  `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	PostID    *uuid.UUID `gorm:"type:uuid;index" json:"postID"`
+	CommentID *uuid.UUID `gorm:"type:uuid;index" json:"commentID"`
+	Content   string     `gorm:"type:text;not null" json:"content"`
+	WrittenAt time.Time  `gorm:"not null" json:"writtenAt"`                   //* when this version was written
+	CreatedAt time.Time  `gorm:"default:current_timestamp" json:"replacedAt"` //* when this version was edited away
+}
@@ -9,5 +9,13 @@ import (
 type SearchQuery struct {
 	ID        uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
 	Query     string    `gorm:"index"`
-	Timestamp time.Time `gorm:"default:current_timestamp" json:"-"`
+	Timestamp time.Time `gorm:"default:current_timestamp;index" json:"-"`
+}
+
+// SearchQueryRollup is the number of times a query was searched on a day, search queries are rolled up into it daily.
+type SearchQueryRollup struct {
+	ID    uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	Query string    `gorm:"uniqueIndex:idx_search_query_rollup;not null" json:"query"`
+	Date  time.Time `gorm:"type:date;uniqueIndex:idx_search_query_rollup;not null" json:"date"`
+	Count int       `gorm:"default:0" json:"count"`
 }
@@ -21,6 +21,7 @@ type Task struct {
 	Project             Project               `gorm:"" json:"project"`
 	OrganizationID      *uuid.UUID            `gorm:"" json:"orgID"`
 	Organization        Organization          `gorm:"" json:"organization"`
+	MilestoneID         *uuid.UUID            `gorm:"type:uuid;index" json:"milestoneID"`
 	Deadline            time.Time             `gorm:"default:current_timestamp" json:"deadline"`
 	Title               string                `gorm:"type:text;not null" json:"title"`
 	Description         string                `gorm:"type:text" json:"description"`
@@ -31,6 +32,7 @@ type Task struct {
 	IsCompleted         bool                  `gorm:"default:false" json:"isCompleted"`
 	CreatedAt           time.Time             `gorm:"default:current_timestamp;index:idx_created_at,sort:desc" json:"createdAt"`
 	OrganizationHistory []OrganizationHistory `gorm:"foreignKey:TaskID;constraint:OnDelete:CASCADE" json:"-"`
+	ProjectHistories    []ProjectHistory      `gorm:"foreignKey:TaskID;constraint:OnDelete:SET NULL" json:"-"` //* the activity of the project stays after the task is deleted
 }
 
 type SubTask struct {
@@ -8,6 +8,14 @@ import (
 	"gorm.io/gorm"
 )
 
+type MentionPrivacy string
+
+const (
+	MentionsFromEveryone  MentionPrivacy = "everyone"
+	MentionsFromFollowing MentionPrivacy = "following" //* only the users this user follows
+	MentionsFromNobody    MentionPrivacy = "nobody"
+)
+
 type User struct {
 	ID                        uuid.UUID            `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
 	Name                      string               `gorm:"type:text;not null" json:"name"`
@@ -40,6 +48,7 @@ type User struct {
 	OrganizationStatus        bool                 `gorm:"default:false" json:"isOrganization"`
 	LastLoggedIn              time.Time            `gorm:"default:current_timestamp" json:"-"`
 	Active                    bool                 `gorm:"default:true" json:"-"`
+	AllowMentionsFrom         MentionPrivacy       `gorm:"type:text;default:everyone" json:"-"` //* who can notify this user by mentioning them
 	CreatedAt                 time.Time            `gorm:"default:current_timestamp;index:idx_created_at,sort:desc" json:"-"`
 	OAuth                     OAuth                `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
 	Profile                   Profile              `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"profile"`
@@ -58,6 +67,8 @@ type User struct {
 	SendNotifications         []Notification       `gorm:"foreignKey:SenderID;constraint:OnDelete:CASCADE" json:"-"`
 	Followers                 []FollowFollower     `gorm:"foreignKey:FollowerID;constraint:OnDelete:CASCADE" json:"-"`
 	Following                 []FollowFollower     `gorm:"foreignKey:FollowedID;constraint:OnDelete:CASCADE" json:"-"`
+	FollowedHashtags          []HashtagFollow      `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
+	PollVotes                 []PollVote           `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
 	Verification              UserVerification     `gorm:"foreignKey:UserID;constraint:OnDelete:CASCADE" json:"-"`
 }
 
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+type WikiPage struct {
+	ID             uuid.UUID      `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	ProjectID      uuid.UUID      `gorm:"type:uuid;not null;uniqueIndex:idx_wiki_page_slug" json:"projectID"`
+	Project        Project        `gorm:"" json:"-"`
+	Title          string         `gorm:"type:text;not null" json:"title"`
+	Slug           string         `gorm:"type:text;not null;uniqueIndex:idx_wiki_page_slug" json:"slug"` //* unique in the project, kept when the title changes so that links do not break
+	Content        string         `gorm:"type:text" json:"content"`                                      //* markdown
+	HTML           string         `gorm:"type:text" json:"html"`                                         //* sanitized render of the content
+	Links          pq.StringArray `gorm:"type:text[]" json:"links"`                                      //* slugs of the pages linked with [[slug]]
+	IsPublic       bool           `gorm:"default:false" json:"isPublic"`                                 //* members only otherwise
+	LatestRevision int            `gorm:"default:1" json:"latestRevision"`
+	Revisions      []WikiRevision `gorm:"foreignKey:PageID;constraint:OnDelete:CASCADE" json:"-"`
+	CreatedByID    uuid.UUID      `gorm:"type:uuid;not null" json:"createdByID"`
+	CreatedBy      User           `gorm:"foreignKey:CreatedByID" json:"createdBy"`
+	LastEditedByID uuid.UUID      `gorm:"type:uuid;not null" json:"lastEditedByID"`
+	LastEditedBy   User           `gorm:"foreignKey:LastEditedByID" json:"lastEditedBy"`
+	CreatedAt      time.Time      `gorm:"default:current_timestamp" json:"createdAt"`
+	UpdatedAt      time.Time      `gorm:"default:current_timestamp" json:"updatedAt"`
+}
+
+type WikiRevision struct {
+	ID         uuid.UUID `gorm:"type:uuid;default:uuid_generate_v4();primary_key" json:"id"`
+	PageID     uuid.UUID `gorm:"type:uuid;not null;index;uniqueIndex:idx_wiki_revision_page_revision" json:"pageID"`
+	Revision   int       `gorm:"not null;uniqueIndex:idx_wiki_revision_page_revision" json:"revision"`
+	Title      string    `gorm:"type:text;not null" json:"title"`
+	Content    string    `gorm:"type:text" json:"content"`
+	EditedByID uuid.UUID `gorm:"type:uuid;not null" json:"editedByID"`
+	EditedBy   User      `gorm:"foreignKey:EditedByID" json:"editedBy"`
+	CreatedAt  time.Time `gorm:"default:current_timestamp" json:"createdAt"`
+}
@@ -10,13 +10,13 @@ import (
 func ApplicationRouter(app *fiber.App) {
 	applicationRoutes := app.Group("/applications", middlewares.Protect)
 
-	applicationRoutes.Get("/:applicationID", middlewares.ProjectRoleAuthorization(models.ProjectManager), project_controllers.GetApplication)
+	applicationRoutes.Get("/:applicationID", middlewares.ProjectRoleAuthorization(models.ProjectApplicationsPermission), project_controllers.GetApplication)
 
-	applicationRoutes.Get("/accept/:applicationID", middlewares.ProjectRoleAuthorization(models.ProjectManager), project_controllers.AcceptApplication)
-	applicationRoutes.Get("/reject/:applicationID", middlewares.ProjectRoleAuthorization(models.ProjectManager), project_controllers.RejectApplication)
-	applicationRoutes.Get("/review/:applicationID", middlewares.ProjectRoleAuthorization(models.ProjectManager), project_controllers.SetApplicationReviewStatus)
+	applicationRoutes.Get("/accept/:applicationID", middlewares.ProjectRoleAuthorization(models.ProjectApplicationsPermission), middlewares.ProjectNotArchived, project_controllers.AcceptApplication)
+	applicationRoutes.Get("/reject/:applicationID", middlewares.ProjectRoleAuthorization(models.ProjectApplicationsPermission), middlewares.ProjectNotArchived, project_controllers.RejectApplication)
+	applicationRoutes.Get("/review/:applicationID", middlewares.ProjectRoleAuthorization(models.ProjectApplicationsPermission), middlewares.ProjectNotArchived, project_controllers.SetApplicationReviewStatus)
 
-	applicationRoutes.Post("/:openingID", project_controllers.AddApplication)
+	applicationRoutes.Post("/:openingID", middlewares.ProjectNotArchived, project_controllers.AddApplication)
 
 	applicationRoutes.Delete("/:applicationID", project_controllers.DeleteApplication)
 }
@@ -12,6 +12,7 @@ func CommentRouter(app *fiber.App) {
 	commentRoutes.Get("/post/:postID", controllers.GetPostComments)
 	commentRoutes.Get("/project/:projectID", controllers.GetProjectComments)
 	commentRoutes.Get("/event/:eventID", controllers.GetEventComments)
+	commentRoutes.Get("/replies/:commentID", controllers.GetCommentReplies)
 	
 	commentRoutes.Post("/", controllers.AddComment)
 
@@ -19,5 +20,8 @@ func CommentRouter(app *fiber.App) {
 
 	commentRoutes.Delete("/:commentID", controllers.DeleteComment)
 
+	commentRoutes.Get("/:commentID/revisions", controllers.GetCommentRevisions)
+	commentRoutes.Get("/:commentID/revisions/diff", controllers.GetCommentRevisionsDiff)
+
 	commentRoutes.Get("/like/:commentID", controllers.LikeComment)
 }
@@ -10,6 +10,8 @@ func Config(app *fiber.App) {
 	OauthRouter(app)
 
 	ConnectionRouter(app)
+	HashtagRouter(app)
+	LinkPreviewRouter(app)
 	PostRouter(app)
 	ProjectRouter(app)
 
@@ -25,7 +27,16 @@ func Config(app *fiber.App) {
 	WorkspaceRouter(app)
 	MembershipRouter(app)
 	ShareRouter(app)
+	ReportRouter(app)
+	HealthRouter(app)
 	TaskRouter(app)
+	MilestoneRouter(app)
+	ProjectFileRouter(app)
+	WikiRouter(app)
+	ProjectRoleRouter(app)
+	ProjectTemplateRouter(app)
+	JoinRequestRouter(app)
+	InviteLinkRouter(app)
 
 	VerificationRouter(app)
 
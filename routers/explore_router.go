@@ -14,12 +14,21 @@ func ExploreRouter(app *fiber.App) {
 	exploreRoutes := app.Group("/explore", middlewares.PartialProtect)
 
 	exploreRoutes.Get("/trending_searches", explore_controllers.GetTrendingSearches)
+	exploreRoutes.Get("/search", explore_controllers.Search)
+	exploreRoutes.Get("/search/autocomplete", explore_controllers.GetSearchAutocomplete)
+	exploreRoutes.Get("/search/recent", middlewares.Protect, explore_controllers.GetRecentSearches)
+	exploreRoutes.Delete("/search/recent", middlewares.Protect, explore_controllers.DeleteRecentSearches)
 	exploreRoutes.Post("/search", explore_controllers.AddSearchQuery)
 
+	exploreRoutes.Get("/recommended", explore_controllers.GetRecommendations)
+
 	exploreRoutes.Get("/posts/trending", explore_controllers.GetTrendingPosts)
 	exploreRoutes.Get("/posts/latest", explore_controllers.GetLatestPosts)
 	exploreRoutes.Get("/posts/recommended", explore_controllers.GetRecommendedPosts)
 
+	exploreRoutes.Get("/hashtags/trending", explore_controllers.GetTrendingHashtags)
+	exploreRoutes.Get("/hashtags/:tag", explore_controllers.GetHashtagPosts)
+
 	exploreRoutes.Get("/openings/recommended", explore_controllers.GetRecommendedOpenings)
 	exploreRoutes.Get("/openings/trending", explore_controllers.GetTrendingOpenings)
 	exploreRoutes.Get("/openings/:slug", explore_controllers.GetProjectOpenings)
@@ -52,6 +61,9 @@ func ExploreRouter(app *fiber.App) {
 	exploreRoutes.Get("/users/projects/contributing/:userID", project_controllers.GetUserContributingProjects)
 
 	exploreRoutes.Get("/users/:username", user_controllers.GetUser)
+	exploreRoutes.Get("/projects/roadmap/:slug", project_controllers.GetProjectRoadmap)
+	exploreRoutes.Get("/projects/wiki/:slug", project_controllers.GetPublicWikiPages)
+	exploreRoutes.Get("/projects/wiki/:slug/:pageSlug", project_controllers.GetPublicWikiPage)
 	exploreRoutes.Get("/projects/:slug", project_controllers.GetProject)
 
 	exploreRoutes.Get("/colleges", explore_controllers.GetColleges)
@@ -0,0 +1,16 @@
+package routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func HashtagRouter(app *fiber.App) {
+	hashtagRoutes := app.Group("/hashtags", middlewares.Protect)
+
+	hashtagRoutes.Get("/following/me", controllers.GetMyFollowedHashtags)
+
+	hashtagRoutes.Get("/follow/:tag", controllers.FollowHashtag)
+	hashtagRoutes.Get("/unfollow/:tag", controllers.UnfollowHashtag)
+}
@@ -0,0 +1,13 @@
+package routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func HealthRouter(app *fiber.App) {
+	healthRoutes := app.Group("/health", middlewares.Protect, middlewares.AdminAuthorization)
+
+	healthRoutes.Get("/ml", controllers.GetMLHealth)
+}
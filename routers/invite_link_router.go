@@ -0,0 +1,18 @@
+package routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func InviteLinkRouter(app *fiber.App) {
+	inviteLinkRoutes := app.Group("/invite_links", middlewares.Protect)
+	inviteLinkRoutes.Get("/join/:code", project_controllers.GetInviteLink)
+	inviteLinkRoutes.Post("/join/:code", project_controllers.JoinProjectWithInviteLink)
+
+	inviteLinkRoutes.Get("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectMembersPermission), project_controllers.GetProjectInviteLinks)
+	inviteLinkRoutes.Post("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectMembersPermission), middlewares.ProjectNotArchived, project_controllers.AddProjectInviteLink)
+	inviteLinkRoutes.Delete("/:inviteLinkID", middlewares.ProjectRoleAuthorization(models.ProjectMembersPermission), project_controllers.DeleteProjectInviteLink)
+}
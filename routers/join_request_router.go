@@ -0,0 +1,19 @@
+package routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func JoinRequestRouter(app *fiber.App) {
+	joinRequestRoutes := app.Group("/join_requests", middlewares.Protect)
+	joinRequestRoutes.Get("/me", project_controllers.GetMyJoinRequests)
+	joinRequestRoutes.Post("/project/:projectID", middlewares.ProjectNotArchived, project_controllers.RequestToJoinProject)
+	joinRequestRoutes.Delete("/:joinRequestID", project_controllers.WithdrawJoinRequest)
+
+	joinRequestRoutes.Get("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectMembersPermission), project_controllers.GetProjectJoinRequests)
+	joinRequestRoutes.Post("/:joinRequestID/accept", middlewares.ProjectRoleAuthorization(models.ProjectMembersPermission), middlewares.ProjectNotArchived, project_controllers.AcceptJoinRequest)
+	joinRequestRoutes.Post("/:joinRequestID/reject", middlewares.ProjectRoleAuthorization(models.ProjectMembersPermission), project_controllers.RejectJoinRequest)
+}
@@ -0,0 +1,13 @@
+package routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func LinkPreviewRouter(app *fiber.App) {
+	previewRoutes := app.Group("/previews", middlewares.Protect)
+
+	previewRoutes.Get("/", controllers.GetLinkPreview)
+}
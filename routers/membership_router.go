@@ -10,8 +10,8 @@ import (
 func MembershipRouter(app *fiber.App) {
 	membershipRoutes := app.Group("/membership", middlewares.Protect)
 	membershipRoutes.Get("/non_members/:projectID", project_controllers.GetNonMembers)
-	membershipRoutes.Post("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectManager), project_controllers.AddMember)
-	membershipRoutes.Patch("/:membershipID", project_controllers.ChangeMemberRole) //* Access handling in controller only
+	membershipRoutes.Post("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectMembersPermission), middlewares.ProjectNotArchived, project_controllers.AddMember)
+	membershipRoutes.Patch("/:membershipID", middlewares.ProjectNotArchived, project_controllers.ChangeMemberRole) //* Access handling in controller only
 	membershipRoutes.Delete("project/:projectID", project_controllers.LeaveProject)
-	membershipRoutes.Delete("/:membershipID", middlewares.ProjectRoleAuthorization(models.ProjectManager), project_controllers.RemoveMember)
+	membershipRoutes.Delete("/:membershipID", middlewares.ProjectRoleAuthorization(models.ProjectMembersPermission), project_controllers.RemoveMember)
 }
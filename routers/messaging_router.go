@@ -27,7 +27,7 @@ func MessagingRouter(app *fiber.App) {
 
 	messagingRoutes.Post("/chat", messaging_controllers.AddChat)
 	messagingRoutes.Post("/group", messaging_controllers.AddGroupChat("Group"))
-	messagingRoutes.Post("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectEditor), messaging_controllers.AddGroupChat("Project"))
+	messagingRoutes.Post("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectChatsPermission), middlewares.ProjectNotArchived, messaging_controllers.AddGroupChat("Project"))
 
 	messagingRoutes.Patch("/chat/last_read/:chatID", messaging_controllers.UpdateLastRead)
 
@@ -35,16 +35,16 @@ func MessagingRouter(app *fiber.App) {
 	messagingRoutes.Post("/chat/unblock", messaging_controllers.UnblockChat)
 	messagingRoutes.Post("/chat/reset", messaging_controllers.ResetChat)
 
-	messagingRoutes.Post("/group/members/add/:chatID", middlewares.GroupChatAdminAuthorization(), messaging_controllers.AddGroupChatMembers("Group"))
-	messagingRoutes.Post("/group/members/remove/:chatID", middlewares.GroupChatAdminAuthorization(), messaging_controllers.RemoveGroupChatMember)
+	messagingRoutes.Post("/group/members/add/:chatID", middlewares.GroupChatAdminAuthorization(), middlewares.ProjectNotArchived, messaging_controllers.AddGroupChatMembers("Group"))
+	messagingRoutes.Post("/group/members/remove/:chatID", middlewares.GroupChatAdminAuthorization(), middlewares.ProjectNotArchived, messaging_controllers.RemoveGroupChatMember)
 
-	messagingRoutes.Patch("/group/:chatID", middlewares.GroupChatAdminAuthorization(), messaging_controllers.EditGroupChat)
-	messagingRoutes.Patch("/group/role/:chatID", middlewares.GroupChatAdminAuthorization(), messaging_controllers.EditGroupChatRole)
+	messagingRoutes.Patch("/group/:chatID", middlewares.GroupChatAdminAuthorization(), middlewares.ProjectNotArchived, messaging_controllers.EditGroupChat)
+	messagingRoutes.Patch("/group/role/:chatID", middlewares.GroupChatAdminAuthorization(), middlewares.ProjectNotArchived, messaging_controllers.EditGroupChatRole)
 
-	messagingRoutes.Delete("/:chatID", middlewares.GroupChatAdminAuthorization(), messaging_controllers.DeleteChat)
-	messagingRoutes.Delete("/group/:chatID", middlewares.GroupChatAdminAuthorization(), messaging_controllers.DeleteGroupChat)
+	messagingRoutes.Delete("/:chatID", middlewares.GroupChatAdminAuthorization(), middlewares.ProjectNotArchived, messaging_controllers.DeleteChat)
+	messagingRoutes.Delete("/group/:chatID", middlewares.GroupChatAdminAuthorization(), middlewares.ProjectNotArchived, messaging_controllers.DeleteGroupChat)
 
-	messagingRoutes.Delete("/group/leave/:chatID", messaging_controllers.LeaveGroupChat) //TODO when admin leaves, then make the first joined person as admin
+	messagingRoutes.Delete("/group/leave/:chatID", middlewares.ProjectNotArchived, messaging_controllers.LeaveGroupChat) //TODO when admin leaves, then make the first joined person as admin
 
 	messagingRoutes.Get("/content/:chatID", messaging_controllers.GetMessages)
 	messagingRoutes.Get("/content/group/:chatID", messaging_controllers.GetGroupChatMessages)
@@ -53,10 +53,10 @@ func MessagingRouter(app *fiber.App) {
 	messagingRoutes.Post("/content/group", messaging_controllers.AddGroupChatMessage)
 
 	messagingRoutes.Delete("/content/:messageID", messaging_controllers.DeleteMessage)
-	messagingRoutes.Delete("/content/project/:messageID", messaging_controllers.DeleteMessage)
+	messagingRoutes.Delete("/content/project/:messageID", middlewares.ProjectNotArchived, messaging_controllers.DeleteMessage)
 
-	messagingRoutes.Post("/group/project/members/add/:chatID", middlewares.GroupChatAdminAuthorization(), messaging_controllers.AddGroupChatMembers("Project"))
-	messagingRoutes.Post("/group/project/members/remove/:chatID", middlewares.GroupChatAdminAuthorization(), messaging_controllers.RemoveGroupChatMember)
+	messagingRoutes.Post("/group/project/members/add/:chatID", middlewares.GroupChatAdminAuthorization(), middlewares.ProjectNotArchived, messaging_controllers.AddGroupChatMembers("Project"))
+	messagingRoutes.Post("/group/project/members/remove/:chatID", middlewares.GroupChatAdminAuthorization(), middlewares.ProjectNotArchived, messaging_controllers.RemoveGroupChatMember)
 
 	messagingRoutes.Post("/group/organization/members/add/:chatID", middlewares.GroupChatAdminAuthorization(), messaging_controllers.AddGroupChatMembers("Organization"))
 	messagingRoutes.Post("/group/organization/members/remove/:chatID", middlewares.GroupChatAdminAuthorization(), messaging_controllers.RemoveGroupChatMember)
@@ -0,0 +1,19 @@
+package routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func MilestoneRouter(app *fiber.App) {
+	milestoneRoutes := app.Group("/milestones", middlewares.Protect)
+	milestoneRoutes.Get("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.GetProjectMilestones)
+	milestoneRoutes.Post("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectTasksPermission), middlewares.ProjectNotArchived, project_controllers.AddMilestone)
+	milestoneRoutes.Patch("/:milestoneID", middlewares.ProjectRoleAuthorization(models.ProjectTasksPermission), middlewares.ProjectNotArchived, project_controllers.UpdateMilestone)
+	milestoneRoutes.Delete("/:milestoneID", middlewares.ProjectRoleAuthorization(models.ProjectTasksPermission), middlewares.ProjectNotArchived, project_controllers.DeleteMilestone)
+
+	milestoneRoutes.Patch("/tasks/:milestoneID", middlewares.ProjectRoleAuthorization(models.ProjectTasksPermission), middlewares.ProjectNotArchived, project_controllers.LinkMilestoneTasks)
+	milestoneRoutes.Delete("/tasks/:milestoneID", middlewares.ProjectRoleAuthorization(models.ProjectTasksPermission), middlewares.ProjectNotArchived, project_controllers.UnlinkMilestoneTasks)
+}
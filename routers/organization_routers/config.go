@@ -13,6 +13,13 @@ func Config(app *fiber.App) {
 	ProjectApplicationRouter(app)
 	ProjectMembershipRouter(app)
 	ProjectOpeningRouter(app)
+	ProjectMilestoneRouter(app)
+	ProjectFileRouter(app)
+	ProjectWikiRouter(app)
+	ProjectRoleRouter(app)
+	ProjectTemplateRouter(app)
+	ProjectJoinRequestRouter(app)
+	ProjectInviteLinkRouter(app)
 	MembershipRouter(app)
 	TaskRouter(app)
 	MiscRouter(app)
@@ -10,6 +10,9 @@ import (
 func PostRouter(app *fiber.App) {
 	postRoutes := app.Group("/org/:orgID/posts", middlewares.Protect, middlewares.OrgRoleAuthorization(models.Senior))
 	postRoutes.Post("/", controllers.AddPost)
+	postRoutes.Get("/drafts", controllers.GetMyDraftPosts)
+	postRoutes.Get("/:postID", controllers.GetPost)
+	postRoutes.Patch("/publish/:postID", controllers.PublishPost)
 	postRoutes.Patch("/:postID", controllers.UpdatePost)
 	postRoutes.Delete("/:postID", controllers.DeletePost)
 }
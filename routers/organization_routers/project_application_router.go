@@ -12,11 +12,11 @@ func ProjectApplicationRouter(app *fiber.App) {
 
 	applicationRoutes.Get("/:applicationID", project_controllers.GetApplication)
 
-	applicationRoutes.Get("/accept/:applicationID", project_controllers.AcceptApplication)
-	applicationRoutes.Get("/reject/:applicationID", project_controllers.RejectApplication)
-	applicationRoutes.Get("/review/:applicationID", project_controllers.SetApplicationReviewStatus)
+	applicationRoutes.Get("/accept/:applicationID", middlewares.ProjectNotArchived, project_controllers.AcceptApplication)
+	applicationRoutes.Get("/reject/:applicationID", middlewares.ProjectNotArchived, project_controllers.RejectApplication)
+	applicationRoutes.Get("/review/:applicationID", middlewares.ProjectNotArchived, project_controllers.SetApplicationReviewStatus)
 
-	applicationRoutes.Post("/:openingID", project_controllers.AddApplication)
+	applicationRoutes.Post("/:openingID", middlewares.ProjectNotArchived, project_controllers.AddApplication)
 
 	applicationRoutes.Delete("/:applicationID", project_controllers.DeleteApplication)
 }
@@ -0,0 +1,23 @@
+package organization_routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ProjectFileRouter(app *fiber.App) {
+	fileRoutes := app.Group("/org/:orgID/files", middlewares.Protect)
+	fileRoutes.Get("/project/:projectID", middlewares.OrgRoleAuthorization(models.Member), middlewares.OrgProjectAuthorization, project_controllers.GetProjectFiles)
+	fileRoutes.Post("/project/:projectID", middlewares.OrgRoleAuthorization(models.Senior), middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.UploadProjectFile)
+	fileRoutes.Get("/:fileID", middlewares.OrgRoleAuthorization(models.Member), middlewares.OrgProjectAuthorization, project_controllers.GetProjectFile)
+	fileRoutes.Get("/download/:fileID", middlewares.OrgRoleAuthorization(models.Member), middlewares.OrgProjectAuthorization, project_controllers.DownloadProjectFile)
+	fileRoutes.Patch("/:fileID", middlewares.OrgRoleAuthorization(models.Senior), middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.UpdateProjectFile)
+	fileRoutes.Delete("/:fileID", middlewares.OrgRoleAuthorization(models.Manager), middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.DeleteProjectFile)
+	fileRoutes.Delete("/:fileID/versions/:version", middlewares.OrgRoleAuthorization(models.Manager), middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.DeleteProjectFileVersion)
+
+	fileRoutes.Post("/folders/project/:projectID", middlewares.OrgRoleAuthorization(models.Senior), middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.AddProjectFolder)
+	fileRoutes.Patch("/folders/:folderID", middlewares.OrgRoleAuthorization(models.Senior), middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.UpdateProjectFolder)
+	fileRoutes.Delete("/folders/:folderID", middlewares.OrgRoleAuthorization(models.Manager), middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.DeleteProjectFolder)
+}
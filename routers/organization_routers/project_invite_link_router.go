@@ -0,0 +1,15 @@
+package organization_routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ProjectInviteLinkRouter(app *fiber.App) {
+	inviteLinkRoutes := app.Group("/org/:orgID/invite_links", middlewares.Protect, middlewares.OrgRoleAuthorization(models.Manager))
+	inviteLinkRoutes.Get("/project/:projectID", middlewares.OrgProjectAuthorization, project_controllers.GetProjectInviteLinks)
+	inviteLinkRoutes.Post("/project/:projectID", middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.AddProjectInviteLink)
+	inviteLinkRoutes.Delete("/:inviteLinkID", middlewares.OrgProjectAuthorization, project_controllers.DeleteProjectInviteLink)
+}
@@ -0,0 +1,15 @@
+package organization_routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ProjectJoinRequestRouter(app *fiber.App) {
+	joinRequestRoutes := app.Group("/org/:orgID/join_requests", middlewares.Protect, middlewares.OrgRoleAuthorization(models.Manager))
+	joinRequestRoutes.Get("/project/:projectID", middlewares.OrgProjectAuthorization, project_controllers.GetProjectJoinRequests)
+	joinRequestRoutes.Post("/:joinRequestID/accept", middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.AcceptJoinRequest)
+	joinRequestRoutes.Post("/:joinRequestID/reject", middlewares.OrgProjectAuthorization, project_controllers.RejectJoinRequest)
+}
@@ -10,8 +10,8 @@ import (
 
 func ProjectMembershipRouter(app *fiber.App) {
 	membershipRoutes := app.Group("/org/:orgID/project/membership", middlewares.Protect, middlewares.OrgRoleAuthorization(models.Manager))
-	membershipRoutes.Post("/initial/:projectID", organization_controllers.AddProjectMembers)
-	membershipRoutes.Post("/:projectID", project_controllers.AddMember)
-	membershipRoutes.Patch("/:membershipID", project_controllers.ChangeMemberRole)
+	membershipRoutes.Post("/initial/:projectID", middlewares.ProjectNotArchived, organization_controllers.AddProjectMembers)
+	membershipRoutes.Post("/:projectID", middlewares.ProjectNotArchived, project_controllers.AddMember)
+	membershipRoutes.Patch("/:membershipID", middlewares.ProjectNotArchived, project_controllers.ChangeMemberRole)
 	membershipRoutes.Delete("/:membershipID", project_controllers.RemoveMember)
 }
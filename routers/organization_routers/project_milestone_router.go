@@ -0,0 +1,20 @@
+package organization_routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ProjectMilestoneRouter(app *fiber.App) {
+	app.Get("/org/:orgID/milestones/project/:projectID", middlewares.Protect, middlewares.OrgRoleAuthorization(models.Member), middlewares.OrgProjectAuthorization, project_controllers.GetProjectMilestones)
+
+	milestoneRoutes := app.Group("/org/:orgID/milestones", middlewares.Protect, middlewares.OrgRoleAuthorization(models.Senior))
+	milestoneRoutes.Post("/project/:projectID", middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.AddMilestone)
+	milestoneRoutes.Patch("/:milestoneID", middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.UpdateMilestone)
+	milestoneRoutes.Delete("/:milestoneID", middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.DeleteMilestone)
+
+	milestoneRoutes.Patch("/tasks/:milestoneID", middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.LinkMilestoneTasks)
+	milestoneRoutes.Delete("/tasks/:milestoneID", middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.UnlinkMilestoneTasks)
+}
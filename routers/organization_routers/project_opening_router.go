@@ -13,7 +13,7 @@ func ProjectOpeningRouter(app *fiber.App) {
 
 	openingRoutes := app.Group("/org/:orgID/openings", middlewares.OrgProtect, middlewares.OrgRoleAuthorization(models.Senior))
 	openingRoutes.Get("/applications/:openingID", project_controllers.GetAllApplicationsOfOpening)
-	openingRoutes.Post("/:projectID", project_controllers.AddOpening)
-	openingRoutes.Patch("/:openingID", project_controllers.EditOpening)
-	openingRoutes.Delete("/:openingID", project_controllers.DeleteOpening)
+	openingRoutes.Post("/:projectID", middlewares.ProjectNotArchived, project_controllers.AddOpening)
+	openingRoutes.Patch("/:openingID", middlewares.ProjectNotArchived, project_controllers.EditOpening)
+	openingRoutes.Delete("/:openingID", middlewares.ProjectNotArchived, project_controllers.DeleteOpening)
 }
@@ -0,0 +1,17 @@
+package organization_routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ProjectRoleRouter(app *fiber.App) {
+	app.Get("/org/:orgID/roles/project/:projectID", middlewares.Protect, middlewares.OrgRoleAuthorization(models.Member), middlewares.OrgProjectAuthorization, project_controllers.GetProjectRoles)
+
+	roleRoutes := app.Group("/org/:orgID/roles", middlewares.Protect, middlewares.OrgRoleAuthorization(models.Manager))
+	roleRoutes.Post("/project/:projectID", middlewares.ProjectNotArchived, project_controllers.AddProjectRole)
+	roleRoutes.Patch("/:roleID", project_controllers.UpdateProjectRole)
+	roleRoutes.Delete("/:roleID", project_controllers.DeleteProjectRole)
+}
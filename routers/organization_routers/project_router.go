@@ -14,10 +14,16 @@ func ProjectRouter(app *fiber.App) {
 	projectRoutes.Get("/tasks/:slug", middlewares.OrgRoleAuthorization(models.Senior), project_controllers.GetWorkSpaceProjectTasks)
 	projectRoutes.Get("/tasks/populated/:slug", middlewares.OrgRoleAuthorization(models.Senior), project_controllers.GetWorkSpacePopulatedProjectTasks)
 	projectRoutes.Get("/history/:projectID", middlewares.OrgRoleAuthorization(models.Member), project_controllers.GetProjectHistory)
+	projectRoutes.Get("/activity/:projectID", middlewares.OrgRoleAuthorization(models.Member), middlewares.OrgProjectAuthorization, project_controllers.GetProjectActivity)
+	projectRoutes.Get("/activity/:projectID/export", middlewares.OrgRoleAuthorization(models.Member), middlewares.OrgProjectAuthorization, project_controllers.ExportProjectActivity)
+	projectRoutes.Get("/analytics/:projectID", middlewares.OrgRoleAuthorization(models.Senior), middlewares.OrgProjectAuthorization, project_controllers.GetProjectAnalytics)
 
 	projectRoutes.Get("/:slug", middlewares.OrgRoleAuthorization(models.Member), project_controllers.GetWorkSpaceProject)
-	projectRoutes.Patch("/:slug", middlewares.OrgRoleAuthorization(models.Senior), project_controllers.UpdateProject)
+	projectRoutes.Patch("/:slug", middlewares.OrgRoleAuthorization(models.Senior), middlewares.ProjectNotArchived, project_controllers.UpdateProject)
 
 	projectRoutes.Get("/delete/:projectID", middlewares.OrgRoleAuthorization(models.Manager), project_controllers.SendDeleteVerificationCode)
 	projectRoutes.Delete("/:projectID", middlewares.OrgRoleAuthorization(models.Manager), project_controllers.DeleteProject)
+
+	projectRoutes.Patch("/archive/:projectID", middlewares.OrgRoleAuthorization(models.Manager), project_controllers.ArchiveProject)
+	projectRoutes.Patch("/unarchive/:projectID", middlewares.OrgRoleAuthorization(models.Manager), project_controllers.UnarchiveProject)
 }
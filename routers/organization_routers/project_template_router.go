@@ -0,0 +1,16 @@
+package organization_routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ProjectTemplateRouter(app *fiber.App) {
+	templateRoutes := app.Group("/org/:orgID/templates", middlewares.Protect)
+	templateRoutes.Get("/", middlewares.OrgRoleAuthorization(models.Member), project_controllers.GetOrgProjectTemplates)
+	templateRoutes.Get("/:templateID", middlewares.OrgRoleAuthorization(models.Member), project_controllers.GetProjectTemplate)
+	templateRoutes.Post("/project/:projectID", middlewares.OrgRoleAuthorization(models.Manager), project_controllers.SaveProjectTemplate)
+	templateRoutes.Delete("/:templateID", middlewares.OrgRoleAuthorization(models.Manager), project_controllers.DeleteProjectTemplate)
+}
@@ -0,0 +1,20 @@
+package organization_routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ProjectWikiRouter(app *fiber.App) {
+	wikiRoutes := app.Group("/org/:orgID/wiki", middlewares.Protect)
+	wikiRoutes.Get("/project/:projectID", middlewares.OrgRoleAuthorization(models.Member), middlewares.OrgProjectAuthorization, project_controllers.GetProjectWikiPages)
+	wikiRoutes.Post("/project/:projectID", middlewares.OrgRoleAuthorization(models.Senior), middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.AddWikiPage)
+	wikiRoutes.Get("/:pageID", middlewares.OrgRoleAuthorization(models.Member), middlewares.OrgProjectAuthorization, project_controllers.GetWikiPage)
+	wikiRoutes.Patch("/:pageID", middlewares.OrgRoleAuthorization(models.Senior), middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.UpdateWikiPage)
+	wikiRoutes.Delete("/:pageID", middlewares.OrgRoleAuthorization(models.Manager), middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.DeleteWikiPage)
+
+	wikiRoutes.Get("/:pageID/revisions", middlewares.OrgRoleAuthorization(models.Member), middlewares.OrgProjectAuthorization, project_controllers.GetWikiRevisions)
+	wikiRoutes.Post("/:pageID/revisions/:revision", middlewares.OrgRoleAuthorization(models.Senior), middlewares.OrgProjectAuthorization, middlewares.ProjectNotArchived, project_controllers.RestoreWikiRevision)
+}
@@ -15,9 +15,9 @@ func TaskRouter(app *fiber.App) {
 	taskRoutes := app.Group("/org/:orgID/tasks", middlewares.Protect, middlewares.OrgRoleAuthorization(models.Senior))
 	taskRoutes.Get("/:taskID", controllers.GetTask("task"))
 	taskRoutes.Post("/", controllers.AddTask("org_task"))
-	taskRoutes.Patch("/:taskID", controllers.EditTask("task"))
-	taskRoutes.Delete("/:taskID", controllers.DeleteTask("task"))
+	taskRoutes.Patch("/:taskID", middlewares.ProjectNotArchived, controllers.EditTask("task"))
+	taskRoutes.Delete("/:taskID", middlewares.ProjectNotArchived, controllers.DeleteTask("task"))
 
-	taskRoutes.Patch("/users/:taskID", controllers.AddTaskUser("org_task"))
-	taskRoutes.Delete("/users/:taskID/:userID", controllers.RemoveTaskUser("task"))
+	taskRoutes.Patch("/users/:taskID", middlewares.ProjectNotArchived, controllers.AddTaskUser("org_task"))
+	taskRoutes.Delete("/users/:taskID/:userID", middlewares.ProjectNotArchived, controllers.RemoveTaskUser("task"))
 }
@@ -11,10 +11,20 @@ func PostRouter(app *fiber.App) {
 	postRoutes.Post("/", controllers.AddPost)
 	postRoutes.Get("/me", controllers.GetMyPosts)
 	postRoutes.Get("/me/likes", controllers.GetMyLikedPosts)
+	postRoutes.Get("/me/drafts", controllers.GetMyDraftPosts)
+	postRoutes.Patch("/publish/:postID", controllers.PublishPost)
 	postRoutes.Get("/:postID", controllers.GetPost)
 	postRoutes.Patch("/:postID", controllers.UpdatePost)
 	postRoutes.Delete("/:postID", controllers.DeletePost)
+	postRoutes.Get("/:postID/reposts", controllers.GetPostReposts)
+	postRoutes.Get("/:postID/revisions", controllers.GetPostRevisions)
+	postRoutes.Get("/:postID/revisions/diff", controllers.GetPostRevisionsDiff)
 
 	postRoutes.Get("/like/:postID", controllers.LikePost)
 
+	postRoutes.Get("/poll/:pollID", controllers.GetPoll)
+	postRoutes.Post("/poll/:pollID/vote", controllers.VotePoll)
+	postRoutes.Delete("/poll/:pollID/vote", controllers.RemovePollVote)
+	postRoutes.Get("/poll/:pollID/voters", controllers.GetPollVoters)
+
 }
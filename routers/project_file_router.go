@@ -0,0 +1,23 @@
+package routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ProjectFileRouter(app *fiber.App) {
+	fileRoutes := app.Group("/files", middlewares.Protect)
+	fileRoutes.Get("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.GetProjectFiles)
+	fileRoutes.Post("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectDocumentsPermission), middlewares.ProjectNotArchived, project_controllers.UploadProjectFile)
+	fileRoutes.Get("/:fileID", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.GetProjectFile)
+	fileRoutes.Get("/download/:fileID", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.DownloadProjectFile)
+	fileRoutes.Patch("/:fileID", middlewares.ProjectRoleAuthorization(models.ProjectDocumentsPermission), middlewares.ProjectNotArchived, project_controllers.UpdateProjectFile)
+	fileRoutes.Delete("/:fileID", middlewares.ProjectRoleAuthorization(models.ProjectDeleteDocumentsPermission), middlewares.ProjectNotArchived, project_controllers.DeleteProjectFile)
+	fileRoutes.Delete("/:fileID/versions/:version", middlewares.ProjectRoleAuthorization(models.ProjectDeleteDocumentsPermission), middlewares.ProjectNotArchived, project_controllers.DeleteProjectFileVersion)
+
+	fileRoutes.Post("/folders/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectDocumentsPermission), middlewares.ProjectNotArchived, project_controllers.AddProjectFolder)
+	fileRoutes.Patch("/folders/:folderID", middlewares.ProjectRoleAuthorization(models.ProjectDocumentsPermission), middlewares.ProjectNotArchived, project_controllers.UpdateProjectFolder)
+	fileRoutes.Delete("/folders/:folderID", middlewares.ProjectRoleAuthorization(models.ProjectDeleteDocumentsPermission), middlewares.ProjectNotArchived, project_controllers.DeleteProjectFolder)
+}
@@ -0,0 +1,16 @@
+package routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ProjectRoleRouter(app *fiber.App) {
+	roleRoutes := app.Group("/roles", middlewares.Protect)
+	roleRoutes.Get("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.GetProjectRoles)
+	roleRoutes.Post("/project/:projectID", middlewares.ProjectNotArchived, project_controllers.AddProjectRole) //* Access handling in controller only
+	roleRoutes.Patch("/:roleID", project_controllers.UpdateProjectRole)
+	roleRoutes.Delete("/:roleID", project_controllers.DeleteProjectRole)
+}
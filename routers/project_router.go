@@ -13,15 +13,28 @@ func ProjectRouter(app *fiber.App) {
 	projectRoutes.Post("/", project_controllers.AddProject)
 	projectRoutes.Get("/me", project_controllers.GetMyProjects)
 	projectRoutes.Get("/me/likes", project_controllers.GetMyLikedProjects)
-	projectRoutes.Get("/:slug", middlewares.ProjectRoleAuthorization(models.ProjectMember), project_controllers.GetWorkSpaceProject)
-	projectRoutes.Get("/chats/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectEditor), project_controllers.GetWorkSpaceProjectChats)
-	projectRoutes.Patch("/:slug", middlewares.ProjectRoleAuthorization(models.ProjectEditor), project_controllers.UpdateProject)
+	projectRoutes.Get("/:slug", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.GetWorkSpaceProject)
+	projectRoutes.Get("/chats/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectChatsPermission), project_controllers.GetWorkSpaceProjectChats)
+	projectRoutes.Patch("/:slug", middlewares.ProjectRoleAuthorization(models.ProjectSettingsPermission), middlewares.ProjectNotArchived, project_controllers.UpdateProject)
 	projectRoutes.Get("/like/:projectID", controllers.LikeProject)
 
-	projectRoutes.Get("/history/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectMember), project_controllers.GetProjectHistory)
-	projectRoutes.Get("/tasks/:slug", middlewares.ProjectRoleAuthorization(models.ProjectMember), project_controllers.GetWorkSpaceProjectTasks)
-	projectRoutes.Get("/tasks/populated/:slug", middlewares.ProjectRoleAuthorization(models.ProjectMember), project_controllers.GetWorkSpacePopulatedProjectTasks)
+	projectRoutes.Get("/history/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.GetProjectHistory)
+	projectRoutes.Get("/activity/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.GetProjectActivity)
+	projectRoutes.Get("/activity/:projectID/export", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.ExportProjectActivity)
+	projectRoutes.Get("/analytics/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectSettingsPermission), project_controllers.GetProjectAnalytics)
+	projectRoutes.Get("/tasks/:slug", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.GetWorkSpaceProjectTasks)
+	projectRoutes.Get("/tasks/populated/:slug", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.GetWorkSpacePopulatedProjectTasks)
 
 	projectRoutes.Get("/delete/:projectID", project_controllers.SendDeleteVerificationCode)
 	projectRoutes.Delete("/:projectID", project_controllers.DeleteProject)
+
+	projectRoutes.Patch("/archive/:projectID", project_controllers.ArchiveProject)
+	projectRoutes.Patch("/unarchive/:projectID", project_controllers.UnarchiveProject)
+
+	projectRoutes.Get("/transfer/me", project_controllers.GetMyOwnershipTransfers)
+	projectRoutes.Get("/transfer/otp/:projectID", project_controllers.SendTransferVerificationCode)
+	projectRoutes.Post("/transfer/:projectID", project_controllers.TransferProject)
+	projectRoutes.Post("/transfer/accept/:transferID", project_controllers.AcceptOwnershipTransfer)
+	projectRoutes.Post("/transfer/reject/:transferID", project_controllers.RejectOwnershipTransfer)
+	projectRoutes.Delete("/transfer/:transferID", project_controllers.CancelOwnershipTransfer)
 }
@@ -0,0 +1,15 @@
+package routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ProjectTemplateRouter(app *fiber.App) {
+	templateRoutes := app.Group("/templates", middlewares.Protect)
+	templateRoutes.Get("/me", project_controllers.GetMyProjectTemplates)
+	templateRoutes.Get("/:templateID", project_controllers.GetProjectTemplate)
+	templateRoutes.Post("/project/:projectID", project_controllers.SaveProjectTemplate) //* Access handling in controller only
+	templateRoutes.Delete("/:templateID", project_controllers.DeleteProjectTemplate)
+}
@@ -0,0 +1,14 @@
+package routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/gofiber/fiber/v2"
+)
+
+func ReportRouter(app *fiber.App) {
+	reportRoutes := app.Group("/reports", middlewares.Protect, middlewares.AdminAuthorization)
+
+	reportRoutes.Get("/", controllers.GetReports)
+	reportRoutes.Get("/:reportID", controllers.GetReport)
+}
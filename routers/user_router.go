@@ -24,6 +24,7 @@ func UserRouter(app *fiber.App) {
 	userRoutes.Get("/me/likes", user_controllers.GetMyLikes)
 	userRoutes.Get("/me/organization/memberships", user_controllers.GetMyOrgMemberships)
 	userRoutes.Get("/views", user_controllers.GetViews)
+	userRoutes.Get("/me/privacy", user_controllers.GetPrivacySettings)
 
 	userRoutes.Patch("/update_password", user_controllers.UpdatePassword)
 	userRoutes.Patch("/update_email", user_controllers.UpdateEmail)
@@ -35,6 +36,7 @@ func UserRouter(app *fiber.App) {
 
 	userRoutes.Patch("/me", user_controllers.UpdateMe)
 	userRoutes.Patch("/me/profile", user_controllers.EditProfile)
+	userRoutes.Patch("/me/privacy", user_controllers.UpdatePrivacySettings)
 	userRoutes.Patch("/me/achievements", user_controllers.AddAchievement)
 	userRoutes.Delete("/me/achievements/:achievementID", user_controllers.DeleteAchievement)
 	// userRoutes.Delete("/me", controllers.DeactivateMe)
@@ -0,0 +1,20 @@
+package routers
+
+import (
+	"github.com/Pratham-Mishra04/interact/controllers/project_controllers"
+	"github.com/Pratham-Mishra04/interact/middlewares"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+func WikiRouter(app *fiber.App) {
+	wikiRoutes := app.Group("/wiki", middlewares.Protect)
+	wikiRoutes.Get("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.GetProjectWikiPages)
+	wikiRoutes.Post("/project/:projectID", middlewares.ProjectRoleAuthorization(models.ProjectDocumentsPermission), middlewares.ProjectNotArchived, project_controllers.AddWikiPage)
+	wikiRoutes.Get("/:pageID", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.GetWikiPage)
+	wikiRoutes.Patch("/:pageID", middlewares.ProjectRoleAuthorization(models.ProjectDocumentsPermission), middlewares.ProjectNotArchived, project_controllers.UpdateWikiPage)
+	wikiRoutes.Delete("/:pageID", middlewares.ProjectRoleAuthorization(models.ProjectDeleteDocumentsPermission), middlewares.ProjectNotArchived, project_controllers.DeleteWikiPage)
+
+	wikiRoutes.Get("/:pageID/revisions", middlewares.ProjectRoleAuthorization(models.ProjectViewPermission), project_controllers.GetWikiRevisions)
+	wikiRoutes.Post("/:pageID/revisions/:revision", middlewares.ProjectRoleAuthorization(models.ProjectDocumentsPermission), middlewares.ProjectNotArchived, project_controllers.RestoreWikiRevision)
+}
@@ -0,0 +1,121 @@
+package routines
+
+import (
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// MarkProjectViewer notes that the user viewed the project today, for counting unique viewers.
+func MarkProjectViewer(projectID uuid.UUID, userID uuid.UUID) {
+	if userID == uuid.Nil {
+		return
+	}
+
+	viewer := models.ProjectViewer{
+		ProjectID: projectID,
+		UserID:    userID,
+		Date:      time.Now().UTC().Truncate(24 * time.Hour),
+	}
+
+	if err := initializers.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&viewer).Error; err != nil {
+		helpers.LogDatabaseError("Error while marking project viewer-MarkProjectViewer", err, "go_routine")
+	}
+}
+
+// addProjectDailyImpressions counts impressions into the rollup of today as they are flushed from the cache,
+// since the impressions of a project are only kept as a running total.
+func addProjectDailyImpressions(projectID string, count int) {
+	if err := initializers.DB.Exec(`
+		INSERT INTO project_daily_stats (project_id, date, impressions) VALUES (?, ?, ?)
+		ON CONFLICT (project_id, date) DO UPDATE SET impressions = project_daily_stats.impressions + EXCLUDED.impressions`,
+		projectID, time.Now().UTC().Format("2006-01-02"), count).Error; err != nil {
+		helpers.LogDatabaseError("Error while adding project impressions-addProjectDailyImpressions", err, "go_routine")
+	}
+}
+
+// RollupProjectAnalytics recounts the daily analytics of all projects for today and the last few days,
+// so that the analytics endpoint only has to read the rollups.
+func RollupProjectAnalytics() {
+	rollupProjectAnalytics(time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -config.ANALYTICS_ROLLUP_WINDOW_DAYS))
+}
+
+// BackfillProjectAnalytics rolls up the whole retention window when nothing has been recounted yet, like right after the rollups
+// are first deployed, so that the longer ranges are not empty. Otherwise it rolls up the last few days like RollupProjectAnalytics.
+func BackfillProjectAnalytics() {
+	var rolledUp bool
+	if err := initializers.DB.Raw(`SELECT EXISTS (
+		SELECT 1 FROM project_daily_stats WHERE views > 0 OR likes > 0 OR comments > 0 OR shares > 0 OR bookmarks > 0 OR applications > 0
+	)`).Scan(&rolledUp).Error; err != nil {
+		helpers.LogDatabaseError("Error while checking project analytics-BackfillProjectAnalytics", err, "go_routine")
+		return
+	}
+
+	if rolledUp {
+		RollupProjectAnalytics()
+		return
+	}
+
+	rollupProjectAnalytics(time.Now().UTC().Truncate(24*time.Hour).AddDate(0, 0, -config.PROJECT_VIEWER_RETENTION_DAYS))
+}
+
+// rollupProjectAnalytics recounts the daily analytics of all projects from the date on. Impressions are counted as they happen,
+// so they are left as they are. The recounted columns are cleared first, so that a day whose likes or comments were all removed does not keep its old counts.
+func rollupProjectAnalytics(from time.Time) {
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			UPDATE project_daily_stats SET views = 0, likes = 0, comments = 0, shares = 0, bookmarks = 0, applications = 0, accepted_applications = 0
+			WHERE date >= @from`,
+			map[string]interface{}{"from": from}).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Exec(`
+			INSERT INTO project_daily_stats (project_id, date, views, likes, comments, shares, bookmarks, applications, accepted_applications)
+			SELECT project_id, day, SUM(views), SUM(likes), SUM(comments), SUM(shares), SUM(bookmarks), SUM(applications), SUM(accepted_applications) FROM (
+				SELECT project_id, DATE(date AT TIME ZONE 'UTC') AS day, SUM(count) AS views, 0 AS likes, 0 AS comments, 0 AS shares, 0 AS bookmarks, 0 AS applications, 0 AS accepted_applications
+				FROM project_views WHERE date >= @from GROUP BY 1, 2
+				UNION ALL
+				SELECT project_id, DATE(created_at AT TIME ZONE 'UTC'), 0, COUNT(*), 0, 0, 0, 0, 0
+				FROM likes WHERE project_id IS NOT NULL AND created_at >= @from GROUP BY 1, 2
+				UNION ALL
+				SELECT project_id, DATE(created_at AT TIME ZONE 'UTC'), 0, 0, COUNT(*), 0, 0, 0, 0
+				FROM comments WHERE project_id IS NOT NULL AND created_at >= @from GROUP BY 1, 2
+				UNION ALL
+				SELECT project_id, DATE(created_at AT TIME ZONE 'UTC'), 0, 0, 0, COUNT(*), 0, 0, 0
+				FROM messages WHERE project_id IS NOT NULL AND created_at >= @from GROUP BY 1, 2
+				UNION ALL
+				SELECT project_id, DATE(created_at AT TIME ZONE 'UTC'), 0, 0, 0, COUNT(*), 0, 0, 0
+				FROM group_chat_messages WHERE project_id IS NOT NULL AND created_at >= @from GROUP BY 1, 2
+				UNION ALL
+				SELECT project_id, DATE(created_at AT TIME ZONE 'UTC'), 0, 0, 0, 0, COUNT(*), 0, 0
+				FROM project_bookmark_items WHERE created_at >= @from GROUP BY 1, 2
+				UNION ALL
+				SELECT project_id, DATE(created_at AT TIME ZONE 'UTC'), 0, 0, 0, 0, 0, COUNT(*), COUNT(*) FILTER (WHERE status = 2)
+				FROM applications WHERE created_at >= @from GROUP BY 1, 2
+			) AS stats
+			WHERE project_id IN (SELECT id FROM projects)
+			GROUP BY project_id, day
+			ON CONFLICT (project_id, date) DO UPDATE SET
+				views = EXCLUDED.views,
+				likes = EXCLUDED.likes,
+				comments = EXCLUDED.comments,
+				shares = EXCLUDED.shares,
+				bookmarks = EXCLUDED.bookmarks,
+				applications = EXCLUDED.applications,
+				accepted_applications = EXCLUDED.accepted_applications`,
+			map[string]interface{}{"from": from}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("date < ?", from.AddDate(0, 0, -config.PROJECT_VIEWER_RETENTION_DAYS)).Delete(&models.ProjectViewer{}).Error
+	}); err != nil {
+		helpers.LogDatabaseError("Error while rolling up project analytics-rollupProjectAnalytics", err, "go_routine")
+	}
+}
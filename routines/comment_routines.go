@@ -5,9 +5,12 @@ import (
 	"github.com/Pratham-Mishra04/interact/initializers"
 	"github.com/Pratham-Mishra04/interact/models"
 	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
-func IncrementPostCommentsAndSendNotification(postID uuid.UUID, loggedInUserID uuid.UUID) {
+// IncrementPostCommentsAndSendNotification counts the comment and notifies the owner of the post, unless they are
+// the one being replied to, since they get the notification of the reply instead.
+func IncrementPostCommentsAndSendNotification(postID uuid.UUID, loggedInUserID uuid.UUID, repliedToUserID uuid.UUID) {
 	var post models.Post
 	if err := initializers.DB.First(&post, "id=?", postID).Error; err != nil {
 		helpers.LogDatabaseError("No Post of this ID found-IncrementPostCommentsAndSendNotification.", err, "go_routine")
@@ -19,7 +22,7 @@ func IncrementPostCommentsAndSendNotification(postID uuid.UUID, loggedInUserID u
 			helpers.LogDatabaseError("Error while updating Post-IncrementPostCommentsAndSendNotification", result.Error, "go_routine")
 		}
 
-		if loggedInUserID != post.UserID {
+		if loggedInUserID != post.UserID && repliedToUserID != post.UserID {
 			notification := models.Notification{
 				SenderID:         loggedInUserID,
 				NotificationType: 2,
@@ -34,7 +37,9 @@ func IncrementPostCommentsAndSendNotification(postID uuid.UUID, loggedInUserID u
 	}
 }
 
-func IncrementProjectCommentsAndSendNotification(projectID uuid.UUID, loggedInUserID uuid.UUID) {
+// IncrementProjectCommentsAndSendNotification counts the comment and notifies the owner of the project, unless they are
+// the one being replied to, since they get the notification of the reply instead.
+func IncrementProjectCommentsAndSendNotification(projectID uuid.UUID, loggedInUserID uuid.UUID, repliedToUserID uuid.UUID) {
 	var project models.Project
 	if err := initializers.DB.First(&project, "id=?", projectID).Error; err != nil {
 		helpers.LogDatabaseError("No Project of this ID found-IncrementProjectCommentsAndSendNotification.", err, "go_routine")
@@ -46,7 +51,7 @@ func IncrementProjectCommentsAndSendNotification(projectID uuid.UUID, loggedInUs
 			helpers.LogDatabaseError("Error while updating Project-IncrementProjectCommentsAndSendNotification", result.Error, "go_routine")
 		}
 
-		if loggedInUserID != project.UserID {
+		if loggedInUserID != project.UserID && repliedToUserID != project.UserID {
 			notification := models.Notification{
 				SenderID:         loggedInUserID,
 				NotificationType: 4,
@@ -61,7 +66,9 @@ func IncrementProjectCommentsAndSendNotification(projectID uuid.UUID, loggedInUs
 	}
 }
 
-func IncrementEventCommentsAndSendNotification(eventID uuid.UUID, loggedInUserID uuid.UUID) {
+// IncrementEventCommentsAndSendNotification counts the comment and notifies the owner of the event, unless they are
+// the one being replied to, since they get the notification of the reply instead.
+func IncrementEventCommentsAndSendNotification(eventID uuid.UUID, loggedInUserID uuid.UUID, repliedToUserID uuid.UUID) {
 	var event models.Event
 	if err := initializers.DB.Preload("Organization").First(&event, "id=?", eventID).Error; err != nil {
 		helpers.LogDatabaseError("No Event of this ID found-IncrementEventCommentsAndSendNotification.", err, "go_routine")
@@ -73,7 +80,7 @@ func IncrementEventCommentsAndSendNotification(eventID uuid.UUID, loggedInUserID
 			helpers.LogDatabaseError("Error while updating Event-IncrementEventCommentsAndSendNotification", result.Error, "go_routine")
 		}
 
-		if loggedInUserID != event.Organization.UserID {
+		if loggedInUserID != event.Organization.UserID && repliedToUserID != event.Organization.UserID {
 			notification := models.Notification{
 				SenderID:         loggedInUserID,
 				NotificationType: 13,
@@ -88,12 +95,15 @@ func IncrementEventCommentsAndSendNotification(eventID uuid.UUID, loggedInUserID
 	}
 }
 
-func DecrementPostComments(postID uuid.UUID) {
+func DecrementPostComments(postID uuid.UUID, count int) {
 	var post models.Post
 	if err := initializers.DB.First(&post, "id=?", postID).Error; err != nil {
 		helpers.LogDatabaseError("No Post of this ID found-DecrementPostComments.", err, "go_routine")
 	} else {
-		post.NoComments--
+		post.NoComments -= count
+		if post.NoComments < 0 {
+			post.NoComments = 0
+		}
 		result := initializers.DB.Save(&post)
 
 		if result.Error != nil {
@@ -102,12 +112,15 @@ func DecrementPostComments(postID uuid.UUID) {
 	}
 }
 
-func DecrementProjectComments(projectID uuid.UUID) {
+func DecrementProjectComments(projectID uuid.UUID, count int) {
 	var project models.Project
 	if err := initializers.DB.First(&project, "id=?", projectID).Error; err != nil {
 		helpers.LogDatabaseError("No Project of this ID found-DecrementProjectComments.", err, "go_routine")
 	} else {
-		project.NoComments--
+		project.NoComments -= count
+		if project.NoComments < 0 {
+			project.NoComments = 0
+		}
 		result := initializers.DB.Save(&project)
 
 		if result.Error != nil {
@@ -116,12 +129,15 @@ func DecrementProjectComments(projectID uuid.UUID) {
 	}
 }
 
-func DecrementEventComments(eventID uuid.UUID) {
+func DecrementEventComments(eventID uuid.UUID, count int) {
 	var event models.Event
 	if err := initializers.DB.First(&event, "id=?", eventID).Error; err != nil {
 		helpers.LogDatabaseError("No Event of this ID found-DecrementEventComments.", err, "go_routine")
 	} else {
-		event.NoComments--
+		event.NoComments -= count
+		if event.NoComments < 0 {
+			event.NoComments = 0
+		}
 		result := initializers.DB.Save(&event)
 
 		if result.Error != nil {
@@ -129,3 +145,40 @@ func DecrementEventComments(eventID uuid.UUID) {
 		}
 	}
 }
+
+// IncrementCommentRepliesAndSendNotification counts a reply under parentID and notifies the author of the comment replied to,
+// which is the parent itself unless the reply was moved up because of the depth limit.
+func IncrementCommentRepliesAndSendNotification(parentID uuid.UUID, repliedToID uuid.UUID, reply models.Comment) {
+	if err := initializers.DB.Model(&models.Comment{}).Where("id = ?", parentID).Update("no_replies", gorm.Expr("no_replies + 1")).Error; err != nil {
+		helpers.LogDatabaseError("Error while updating Comment-IncrementCommentRepliesAndSendNotification", err, "go_routine")
+	}
+
+	var repliedTo models.Comment
+	if err := initializers.DB.First(&repliedTo, "id = ?", repliedToID).Error; err != nil {
+		helpers.LogDatabaseError("No Comment of this ID found-IncrementCommentRepliesAndSendNotification.", err, "go_routine")
+		return
+	}
+
+	if repliedTo.UserID == reply.UserID || IsBlocked(reply.UserID, repliedTo.UserID) {
+		return
+	}
+
+	notification := models.Notification{
+		SenderID:         reply.UserID,
+		NotificationType: 22,
+		UserID:           repliedTo.UserID,
+		PostID:           reply.PostID,
+		ProjectID:        reply.ProjectID,
+		EventID:          reply.EventID,
+	}
+
+	if err := initializers.DB.Create(&notification).Error; err != nil {
+		helpers.LogDatabaseError("Error while creating Notification-IncrementCommentRepliesAndSendNotification", err, "go_routine")
+	}
+}
+
+func DecrementCommentReplies(parentID uuid.UUID) {
+	if err := initializers.DB.Model(&models.Comment{}).Where("id = ?", parentID).Update("no_replies", gorm.Expr("GREATEST(no_replies - 1, 0)")).Error; err != nil {
+		helpers.LogDatabaseError("Error while updating Comment-DecrementCommentReplies", err, "go_routine")
+	}
+}
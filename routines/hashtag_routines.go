@@ -0,0 +1,51 @@
+package routines
+
+import (
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"gorm.io/gorm"
+)
+
+func IncrementHashtagPosts(hashtags []models.Hashtag) {
+	updateHashtagPosts(hashtags, 1)
+}
+
+func DecrementHashtagPosts(hashtags []models.Hashtag) {
+	updateHashtagPosts(hashtags, -1)
+}
+
+func updateHashtagPosts(hashtags []models.Hashtag, delta int) {
+	for _, hashtag := range hashtags {
+		if err := initializers.DB.Model(&models.Hashtag{}).
+			Where("id = ?", hashtag.ID).
+			UpdateColumn("no_posts", gorm.Expr("GREATEST(no_posts + ?, 0)", delta)).Error; err != nil {
+			helpers.LogDatabaseError("Error while updating hashtag-updateHashtagPosts", err, "go_routine")
+		}
+	}
+}
+
+// HashtagsDiff returns the hashtags present only in newHashtags and only in oldHashtags respectively.
+func HashtagsDiff(oldHashtags []models.Hashtag, newHashtags []models.Hashtag) ([]models.Hashtag, []models.Hashtag) {
+	oldSet := make(map[string]bool)
+	for _, hashtag := range oldHashtags {
+		oldSet[hashtag.Name] = true
+	}
+	newSet := make(map[string]bool)
+	for _, hashtag := range newHashtags {
+		newSet[hashtag.Name] = true
+	}
+
+	var added, removed []models.Hashtag
+	for _, hashtag := range newHashtags {
+		if !oldSet[hashtag.Name] {
+			added = append(added, hashtag)
+		}
+	}
+	for _, hashtag := range oldHashtags {
+		if !newSet[hashtag.Name] {
+			removed = append(removed, hashtag)
+		}
+	}
+	return added, removed
+}
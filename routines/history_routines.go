@@ -101,6 +101,19 @@ func MarkProjectHistory(
 	}
 }
 
+func MarkMilestoneHistory(projectID uuid.UUID, senderID uuid.UUID, historyType int, milestoneID uuid.UUID) {
+	history := models.ProjectHistory{
+		ProjectID:   projectID,
+		SenderID:    senderID,
+		HistoryType: historyType,
+		MilestoneID: &milestoneID,
+	}
+
+	if err := initializers.DB.Create(&history).Error; err != nil {
+		helpers.LogDatabaseError("Error while creating Project History-MarkMilestoneHistory", err, "go_routine")
+	}
+}
+
 func MarkOrganizationHistory(
 	orgID uuid.UUID,
 	userID uuid.UUID,
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/config"
 	"github.com/Pratham-Mishra04/interact/helpers"
 	"github.com/Pratham-Mishra04/interact/initializers"
 	"github.com/Pratham-Mishra04/interact/models"
@@ -31,7 +32,7 @@ func IncrementImpressions(items interface{}, getModelID func(interface{}) string
 		impressionCount, err := cache.GetImpression(key)
 		if err != nil {
 			return
-		} else if impressionCount >= 9 {
+		} else if impressionCount >= config.IMPRESSIONS_FLUSH_COUNT-1 {
 			itemIDs = append(itemIDs, key)
 			checkForNotification(item, modelType, impressionCount)
 			go cache.ResetImpression(key)
@@ -59,14 +60,15 @@ func incrementImpressionsConcurrently(ids []string, workerCount int, incrementDB
 	}()
 }
 
-func incrementDBImpressions(modelType interface{}, itemID string, ch chan<- uint) {
-	result := initializers.DB.Model(modelType).Where("id = ?", itemID).UpdateColumn("Impressions", gorm.Expr("Impressions + ?", 10))
+func incrementDBImpressions(modelType interface{}, itemID string, ch chan<- uint) bool {
+	result := initializers.DB.Model(modelType).Where("id = ?", itemID).UpdateColumn("Impressions", gorm.Expr("Impressions + ?", config.IMPRESSIONS_FLUSH_COUNT))
 	if result.Error != nil {
 		typeName := reflect.TypeOf(modelType).Elem().Name()
 		helpers.LogDatabaseError(fmt.Sprintf("Error updating %sImpressionCount", typeName), result.Error, "impression_routines")
-		return
+		return false
 	}
 	ch <- 1
+	return true
 }
 
 // Posts
@@ -88,7 +90,9 @@ func IncrementProjectImpression(projects []models.Project) {
 }
 
 func incrementDBProjectImpressions(projectID string, ch chan<- uint) {
-	incrementDBImpressions(&models.Project{}, projectID, ch)
+	if incrementDBImpressions(&models.Project{}, projectID, ch) {
+		addProjectDailyImpressions(projectID, config.IMPRESSIONS_FLUSH_COUNT)
+	}
 }
 
 // Events
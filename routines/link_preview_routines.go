@@ -0,0 +1,93 @@
+package routines
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/utils/unfurl"
+)
+
+// LinkPreviewFetcher can be replaced with a fetcher pointed at a local stub server.
+var LinkPreviewFetcher unfurl.PreviewFetcher = unfurl.NewFetcher(unfurl.DefaultOptions())
+
+// GetLinkPreview returns the cached preview of url, fetching it on a miss. Failed fetches are cached as empty previews so a broken link is not fetched again on every request.
+func GetLinkPreview(url string) (*unfurl.Preview, error) {
+	if preview, err := cache.GetLinkPreview(url); err == nil {
+		return preview, nil
+	}
+
+	preview, err := LinkPreviewFetcher.Fetch(context.Background(), url)
+	if err != nil {
+		go cache.SetLinkPreview(url, &unfurl.Preview{URL: url})
+		return nil, err
+	}
+
+	go cache.SetLinkPreview(url, preview)
+	return preview, nil
+}
+
+// StoreLinkPreviews replaces the previews of the post, message, project or event set on owner with the previews of the links in content.
+// It is run in the background so that the write does not wait on remote hosts, and removes the cached owner once the previews are stored.
+func StoreLinkPreviews(owner models.LinkPreview, content string) {
+	if err := initializers.DB.Where(&owner).Delete(&models.LinkPreview{}).Error; err != nil {
+		helpers.LogDatabaseError("Error while deleting link previews-StoreLinkPreviews", err, "go_routine")
+		return
+	}
+
+	urls := unfurl.ExtractURLs(content, config.MAX_LINK_PREVIEWS)
+
+	//* the links are fetched together, so the wait is that of the slowest one and not of all of them
+	previews := make([]*unfurl.Preview, len(urls))
+	var wg sync.WaitGroup
+	for i, url := range urls {
+		wg.Add(1)
+		go func(i int, url string) {
+			defer wg.Done()
+			if preview, err := GetLinkPreview(url); err == nil && !preview.IsEmpty() {
+				previews[i] = preview
+			}
+		}(i, url)
+	}
+	wg.Wait()
+
+	for _, preview := range previews {
+		if preview == nil {
+			continue
+		}
+
+		linkPreview := owner
+		linkPreview.URL = preview.URL
+		linkPreview.Title = preview.Title
+		linkPreview.Description = preview.Description
+		linkPreview.Image = preview.Image
+		linkPreview.SiteName = preview.SiteName
+
+		if err := initializers.DB.Create(&linkPreview).Error; err != nil {
+			helpers.LogDatabaseError("Error while creating link preview-StoreLinkPreviews", err, "go_routine")
+		}
+	}
+
+	removeLinkPreviewOwnerFromCache(owner)
+}
+
+func removeLinkPreviewOwnerFromCache(owner models.LinkPreview) {
+	switch {
+	case owner.PostID != nil:
+		cache.RemovePost(owner.PostID.String())
+	case owner.EventID != nil:
+		cache.RemoveEvent(owner.EventID.String())
+	case owner.ProjectID != nil:
+		var project models.Project
+		if err := initializers.DB.Select("slug").First(&project, "id = ?", *owner.ProjectID).Error; err != nil {
+			helpers.LogDatabaseError("Error while fetching project-removeLinkPreviewOwnerFromCache", err, "go_routine")
+			return
+		}
+		cache.RemoveProject(project.Slug)
+		cache.RemoveProject("-workspace--" + project.Slug)
+	}
+}
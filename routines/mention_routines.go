@@ -0,0 +1,80 @@
+package routines
+
+import (
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/google/uuid"
+)
+
+// IsBlocked reports whether either user has blocked the other in their personal chat.
+func IsBlocked(userID uuid.UUID, otherUserID uuid.UUID) bool {
+	var count int64
+	if err := initializers.DB.Model(&models.Chat{}).
+		Where("(creating_user_id = ? AND accepting_user_id = ?) OR (creating_user_id = ? AND accepting_user_id = ?)", userID, otherUserID, otherUserID, userID).
+		Where("blocked_by_creating_user = ? OR blocked_by_accepting_user = ?", true, true).
+		Count(&count).Error; err != nil {
+		helpers.LogDatabaseError("Error while checking blocks-IsBlocked", err, "go_routine")
+		return false
+	}
+	return count > 0
+}
+
+// allowsMentionFrom reports whether the privacy settings of the user let the sender notify them with a mention.
+func allowsMentionFrom(userID uuid.UUID, senderID uuid.UUID) bool {
+	var user models.User
+	if err := initializers.DB.Select("id", "allow_mentions_from").First(&user, "id = ?", userID).Error; err != nil {
+		helpers.LogDatabaseError("Error while fetching user-allowsMentionFrom", err, "go_routine")
+		return false
+	}
+
+	switch user.AllowMentionsFrom {
+	case models.MentionsFromNobody:
+		return false
+	case models.MentionsFromFollowing:
+		var count int64
+		if err := initializers.DB.Model(&models.FollowFollower{}).
+			Where("follower_id = ? AND followed_id = ?", userID, senderID).
+			Count(&count).Error; err != nil {
+			helpers.LogDatabaseError("Error while checking follow-allowsMentionFrom", err, "go_routine")
+			return false
+		}
+		return count > 0
+	default:
+		return true
+	}
+}
+
+/*
+notificationType should be one of:
+*17 - mentioned in a post
+*18 - mentioned in a comment
+*19 - mentioned in a message
+*/
+func SendMentionNotifications(senderID uuid.UUID, mentions []models.Mention, notificationType int, postID *uuid.UUID, projectID *uuid.UUID, eventID *uuid.UUID) {
+	notified := make(map[uuid.UUID]bool)
+
+	for _, mention := range mentions {
+		if mention.UserID == senderID || notified[mention.UserID] {
+			continue
+		}
+		notified[mention.UserID] = true
+
+		if IsBlocked(senderID, mention.UserID) || !allowsMentionFrom(mention.UserID, senderID) {
+			continue
+		}
+
+		notification := models.Notification{
+			NotificationType: notificationType,
+			UserID:           mention.UserID,
+			SenderID:         senderID,
+			PostID:           postID,
+			ProjectID:        projectID,
+			EventID:          eventID,
+		}
+
+		if err := initializers.DB.Create(&notification).Error; err != nil {
+			helpers.LogDatabaseError("Error while creating notification-SendMentionNotifications", err, "go_routine")
+		}
+	}
+}
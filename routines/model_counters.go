@@ -207,4 +207,30 @@ func IncrementReposts(postID uuid.UUID) {
 			helpers.LogDatabaseError("Error while updating Post-IncrementReposts", err, "go_routine")
 		}
 	}
-}
\ No newline at end of file
+}
+
+func DecrementReposts(postID uuid.UUID) {
+	var post models.Post
+	if err := initializers.DB.First(&post, "id = ?", postID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			helpers.LogDatabaseError("No Post of this ID found-DecrementReposts.", err, "go_routine")
+		} else {
+			helpers.LogDatabaseError("Error while fetching Post-DecrementReposts", err, "go_routine")
+		}
+	} else {
+		if post.NoOfReposts > 0 {
+			post.NoOfReposts--
+		}
+		if err := initializers.DB.Save(&post).Error; err != nil {
+			helpers.LogDatabaseError("Error while updating Post-DecrementReposts", err, "go_routine")
+		}
+	}
+}
+
+// TransferUserProject moves a project between the counters of its previous and new owner.
+func TransferUserProject(fromUserID uuid.UUID, toUserID uuid.UUID) {
+	DecrementUserProject(fromUserID)
+	IncrementUserProject(toUserID)
+	setUserCollaborativeProject(fromUserID)
+	setUserCollaborativeProject(toUserID)
+}
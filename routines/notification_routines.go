@@ -107,3 +107,121 @@ func sendImpressionNotification(userID uuid.UUID,
 		helpers.LogDatabaseError("Error whiling creating notification-SendImpressionNotification", result.Error, "go_routine")
 	}
 }
+
+func SendOwnershipTransferNotification(userID uuid.UUID, senderID uuid.UUID, projectID uuid.UUID) {
+	notification := models.Notification{
+		NotificationType: 23,
+		UserID:           userID,
+		SenderID:         senderID,
+		ProjectID:        &projectID,
+	}
+	result := initializers.DB.Create(&notification)
+	if result.Error != nil {
+		helpers.LogDatabaseError("Error whiling creating notification-SendOwnershipTransferNotification", result.Error, "go_routine")
+	}
+}
+
+func SendOwnershipAcceptedNotification(userID uuid.UUID, senderID uuid.UUID, projectID uuid.UUID) {
+	notification := models.Notification{
+		NotificationType: 24,
+		UserID:           userID,
+		SenderID:         senderID,
+		ProjectID:        &projectID,
+	}
+	result := initializers.DB.Create(&notification)
+	if result.Error != nil {
+		helpers.LogDatabaseError("Error whiling creating notification-SendOwnershipAcceptedNotification", result.Error, "go_routine")
+	}
+}
+
+// SendMilestoneNotification tells the owner and members of a project, other than the sender, that a milestone was completed.
+func SendMilestoneNotification(senderID uuid.UUID, projectID uuid.UUID) {
+	var project models.Project
+	if err := initializers.DB.Preload("Memberships").First(&project, "id = ?", projectID).Error; err != nil {
+		helpers.LogDatabaseError("Error whiling fetching project-SendMilestoneNotification", err, "go_routine")
+		return
+	}
+
+	userIDs := []uuid.UUID{project.UserID}
+	for _, membership := range project.Memberships {
+		userIDs = append(userIDs, membership.UserID)
+	}
+
+	var notifications []models.Notification
+	for _, userID := range userIDs {
+		if userID == senderID {
+			continue
+		}
+		notifications = append(notifications, models.Notification{
+			NotificationType: 25,
+			UserID:           userID,
+			SenderID:         senderID,
+			ProjectID:        &projectID,
+		})
+	}
+
+	if len(notifications) == 0 {
+		return
+	}
+
+	if err := initializers.DB.Create(&notifications).Error; err != nil {
+		helpers.LogDatabaseError("Error whiling creating notifications-SendMilestoneNotification", err, "go_routine")
+	}
+}
+
+// SendJoinRequestNotification tells the owner, and the members who can manage members, that a user wants to join the project.
+func SendJoinRequestNotification(senderID uuid.UUID, projectID uuid.UUID) {
+	var project models.Project
+	if err := initializers.DB.Preload("Memberships").Preload("Roles").First(&project, "id = ?", projectID).Error; err != nil {
+		helpers.LogDatabaseError("Error whiling fetching project-SendJoinRequestNotification", err, "go_routine")
+		return
+	}
+
+	notifications := []models.Notification{{
+		NotificationType: 26,
+		UserID:           project.UserID,
+		SenderID:         senderID,
+		ProjectID:        &projectID,
+	}}
+	for _, membership := range project.Memberships {
+		if !models.HasPermission(project.Roles, membership.Role, models.ProjectMembersPermission) {
+			continue
+		}
+		notifications = append(notifications, models.Notification{
+			NotificationType: 26,
+			UserID:           membership.UserID,
+			SenderID:         senderID,
+			ProjectID:        &projectID,
+		})
+	}
+
+	if err := initializers.DB.Create(&notifications).Error; err != nil {
+		helpers.LogDatabaseError("Error whiling creating notifications-SendJoinRequestNotification", err, "go_routine")
+	}
+}
+
+func SendJoinRequestAcceptedNotification(userID uuid.UUID, senderID uuid.UUID, projectID uuid.UUID) {
+	notification := models.Notification{
+		NotificationType: 27,
+		UserID:           userID,
+		SenderID:         senderID,
+		ProjectID:        &projectID,
+	}
+	result := initializers.DB.Create(&notification)
+	if result.Error != nil {
+		helpers.LogDatabaseError("Error whiling creating notification-SendJoinRequestAcceptedNotification", result.Error, "go_routine")
+	}
+}
+
+func SendInviteLinkJoinedNotification(userID uuid.UUID, senderID uuid.UUID, projectID uuid.UUID) {
+	notification := models.Notification{
+		NotificationType: 28,
+		UserID:           userID,
+		SenderID:         senderID,
+		ProjectID:        &projectID,
+	}
+	result := initializers.DB.Create(&notification)
+	if result.Error != nil {
+		helpers.LogDatabaseError("Error whiling creating notification-SendInviteLinkJoinedNotification", result.Error, "go_routine")
+	}
+}
@@ -0,0 +1,81 @@
+package routines
+
+import (
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+)
+
+// HandlePostPublished runs the side effects of a post going live, post should have Mentions and Hashtags preloaded.
+func HandlePostPublished(post models.Post) {
+	if post.OrganizationID != nil && post.OrgMemberID != nil {
+		MarkOrganizationHistory(*post.OrganizationID, *post.OrgMemberID, 6, &post.ID, nil, nil, nil, nil, "")
+	}
+	if post.RePostID != nil {
+		IncrementReposts(*post.RePostID)
+		SendRepostNotification(post)
+	}
+
+	SendMentionNotifications(post.UserID, post.Mentions, 17, &post.ID, nil, nil)
+	IncrementHashtagPosts(post.Hashtags)
+}
+
+func SendRepostNotification(post models.Post) {
+	var rePost models.Post
+	if err := initializers.DB.First(&rePost, "id = ?", *post.RePostID).Error; err != nil {
+		helpers.LogDatabaseError("Error while fetching reposted Post-SendRepostNotification", err, "go_routine")
+		return
+	}
+
+	if rePost.UserID == post.UserID || IsBlocked(post.UserID, rePost.UserID) {
+		return
+	}
+
+	notificationType := 20
+	if post.Content != "" {
+		notificationType = 21
+	}
+
+	notification := models.Notification{
+		NotificationType: notificationType,
+		UserID:           rePost.UserID,
+		SenderID:         post.UserID,
+		PostID:           &post.ID,
+	}
+
+	if err := initializers.DB.Create(&notification).Error; err != nil {
+		helpers.LogDatabaseError("Error while creating notification-SendRepostNotification", err, "go_routine")
+	}
+}
+
+func PublishScheduledPosts() {
+	var posts []models.Post
+	if err := initializers.DB.
+		Preload("Mentions").
+		Preload("Hashtags").
+		Where("status = ? AND scheduled_for <= ?", models.PostScheduled, time.Now()).
+		Find(&posts).Error; err != nil {
+		helpers.LogDatabaseError("Error while fetching scheduled posts-PublishScheduledPosts", err, "go_routine")
+		return
+	}
+
+	for _, post := range posts {
+		//* conditional update so that a post published meanwhile by its author is not published twice
+		result := initializers.DB.Model(&models.Post{}).
+			Where("id = ? AND status = ?", post.ID, models.PostScheduled).
+			Updates(map[string]any{"status": models.PostPublished, "created_at": time.Now()})
+		if result.Error != nil {
+			helpers.LogDatabaseError("Error while publishing post-PublishScheduledPosts", result.Error, "go_routine")
+			continue
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		cache.RemovePost(post.ID.String())
+		HandlePostPublished(post)
+	}
+}
@@ -0,0 +1,12 @@
+package routines
+
+import (
+	"github.com/Pratham-Mishra04/interact/helpers"
+)
+
+// DeleteProjectFiles removes the stored versions of deleted project files from the bucket.
+func DeleteProjectFiles(paths []string) {
+	for _, path := range paths {
+		DeleteFromBucket(helpers.ProjectFileClient, path)
+	}
+}
@@ -0,0 +1,175 @@
+package routines
+
+import (
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/Pratham-Mishra04/interact/utils"
+	"gorm.io/gorm"
+)
+
+// LogSearchQuery stores a search for trending searches, autocomplete and the recent searches of the user.
+// Searches which look like personal data are dropped, and a searcher repeating a search counts once.
+func LogSearchQuery(query string, userID string, ip string) {
+	query = utils.NormalizeSearchTerm(query)
+	if query == "" || utils.IsSensitiveSearch(query) {
+		return
+	}
+
+	if userID != "" {
+		cache.AddRecentSearch(userID, query)
+	}
+
+	searcher := userID
+	if searcher == "" {
+		searcher = ip
+	}
+	if searcher != "" {
+		if first, err := cache.MarkSearched(searcher, query); err == nil && !first {
+			return
+		}
+	}
+
+	searchQuery := models.SearchQuery{
+		Query: query,
+	}
+	if err := initializers.DB.Create(&searchQuery).Error; err != nil {
+		helpers.LogDatabaseError("Error while creating search query-LogSearchQuery", err, "go_routine")
+	}
+
+	cache.IncrementTrendingSearch(query)
+	cache.IncrementAutocompleteTerm(query)
+}
+
+// RollupSearchQueries counts the search queries of previous days into daily rollups and deletes them,
+// then decays the trending searches.
+func RollupSearchQueries() {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	if err := initializers.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(`
+			INSERT INTO search_query_rollups (query, date, count)
+			SELECT LOWER(TRIM(query)), DATE(timestamp), COUNT(*) FROM search_queries
+			WHERE timestamp < ?
+			GROUP BY LOWER(TRIM(query)), DATE(timestamp)
+			ON CONFLICT (query, date) DO UPDATE SET count = search_query_rollups.count + EXCLUDED.count`, today).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Where("timestamp < ?", today).Delete(&models.SearchQuery{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("date < ?", today.AddDate(0, 0, -config.SEARCH_ROLLUP_RETENTION_DAYS)).Delete(&models.SearchQueryRollup{}).Error
+	}); err != nil {
+		helpers.LogDatabaseError("Error while rolling up search queries-RollupSearchQueries", err, "go_routine")
+		return
+	}
+
+	cache.DecayTrendingSearches()
+}
+
+// SeedTrendingSearches rebuilds the trending searches from the database when they are missing from the cache.
+func SeedTrendingSearches() {
+	if exists, err := cache.HasTrendingSearches(); err != nil || exists {
+		return
+	}
+
+	var searches []struct {
+		Query string
+		Score float64
+	}
+	if err := initializers.DB.Raw(`
+		SELECT query, SUM(weight) AS score FROM (
+			SELECT query, count * POWER(2, -EXTRACT(EPOCH FROM NOW() - date::timestamptz) / 3600 / ?) AS weight FROM search_query_rollups WHERE date > ?
+			UNION ALL
+			SELECT query, POWER(2, -EXTRACT(EPOCH FROM NOW() - timestamp) / 3600 / ?) AS weight FROM search_queries
+		) AS searches
+		GROUP BY query
+		ORDER BY score DESC
+		LIMIT ?`,
+		config.TRENDING_SEARCH_HALF_LIFE_HOURS, time.Now().AddDate(0, 0, -config.AUTOCOMPLETE_HISTORY_DAYS),
+		config.TRENDING_SEARCH_HALF_LIFE_HOURS, config.TRENDING_SEARCH_SEED,
+	).Scan(&searches).Error; err != nil {
+		helpers.LogDatabaseError("Error while fetching search queries-SeedTrendingSearches", err, "go_routine")
+		return
+	}
+
+	scores := make(map[string]float64, len(searches))
+	for _, search := range searches {
+		if term := utils.NormalizeSearchTerm(search.Query); term != "" && !utils.IsSensitiveSearch(term) {
+			scores[term] += search.Score
+		}
+	}
+
+	cache.SetTrendingSearches(scores)
+}
+
+// SeedAutocomplete rebuilds autocomplete from the most searched queries and the titles of projects, openings, events, orgs and hashtags,
+// so queries nobody searches anymore and titles of removed items are dropped on every run.
+func SeedAutocomplete() {
+	terms := make(map[string]float64)
+
+	var queries []struct {
+		Query string
+		Count int
+	}
+	since := time.Now().AddDate(0, 0, -config.AUTOCOMPLETE_HISTORY_DAYS)
+	if err := initializers.DB.Raw(`
+		SELECT query, SUM(count) AS count FROM (
+			SELECT query, count FROM search_query_rollups WHERE date > ?
+			UNION ALL
+			SELECT query, 1 AS count FROM search_queries WHERE timestamp > ?
+		) AS searches
+		GROUP BY query
+		ORDER BY count DESC
+		LIMIT ?`, since, since, config.AUTOCOMPLETE_SEED_QUERIES).
+		Scan(&queries).Error; err != nil {
+		helpers.LogDatabaseError("Error while fetching search queries-SeedAutocomplete", err, "go_routine")
+		return
+	}
+
+	for _, query := range queries {
+		if term := utils.NormalizeSearchTerm(query.Query); term != "" && !utils.IsSensitiveSearch(term) {
+			terms[term] += float64(query.Count)
+		}
+	}
+
+	titleQueries := []struct {
+		model  interface{}
+		column string
+		joins  string
+		where  string
+	}{
+		{&models.Project{}, "title", "", "is_private = false AND is_archived = false"},
+		{&models.Opening{}, "openings.title", "JOIN projects ON projects.id = openings.project_id", "openings.active = true AND projects.is_private = false AND projects.is_archived = false"},
+		{&models.Event{}, "title", "", "end_time > NOW()"},
+		{&models.Organization{}, "organization_title", "", "organization_title <> ''"},
+		{&models.Hashtag{}, "name", "", "no_posts > 0"},
+	}
+
+	for _, titleQuery := range titleQueries {
+		var titles []string
+		db := initializers.DB.Model(titleQuery.model)
+		if titleQuery.joins != "" {
+			db = db.Joins(titleQuery.joins)
+		}
+		if err := db.Where(titleQuery.where).Pluck(titleQuery.column, &titles).Error; err != nil {
+			helpers.LogDatabaseError("Error while fetching titles-SeedAutocomplete", err, "go_routine")
+			continue
+		}
+
+		for _, title := range titles {
+			term := utils.NormalizeSearchTerm(title)
+			if _, ok := terms[term]; term != "" && !ok {
+				terms[term] = 1 //* titles nobody searched for rank below searched queries
+			}
+		}
+	}
+
+	cache.ReplaceAutocompleteTerms(terms)
+}
@@ -3,14 +3,27 @@ package schemas
 import "github.com/lib/pq"
 
 type PostCreateSchema struct { // from request
-	Content         string         `json:"content" validate:"required,max=2000"`
+	Content         string         `json:"content" validate:"required_without=RePostID,max=2000"` //* a repost without content is a plain repost, with content it is a quote
 	Tags            pq.StringArray `json:"tags" validate:"dive,alphanum"`
 	TaggedUsernames pq.StringArray `json:"taggedUsernames"`
 	RePostID        string         `json:"rePostID"`
+	IsDraft         bool           `json:"isDraft"`
+	Visibility      string         `json:"visibility" validate:"omitempty,oneof=public followers mentioned members"`
+	ScheduledFor    string         `json:"scheduledFor"` //* RFC3339, publishes the post at this time
+	PollOptions     pq.StringArray `json:"pollOptions" validate:"omitempty,min=2,max=6,unique,dive,required,max=50"`
+	PollMultiChoice bool           `json:"pollMultiChoice"`
+	PollAnonymous   bool           `json:"pollAnonymous"`
+	PollEndsAt      string         `json:"pollEndsAt"` //* RFC3339, poll stays open forever if empty
+}
+
+type PollVoteSchema struct {
+	OptionIDs []string `json:"optionIDs" validate:"required,min=1,max=6,unique,dive,uuid"`
 }
 
 type PostUpdateSchema struct {
 	Content         string          `json:"content" validate:"max=2000"`
 	Tags            *pq.StringArray `json:"tags" validate:"dive,alphanum"`
 	TaggedUsernames pq.StringArray  `json:"taggedUsernames"`
+	ScheduledFor    *string         `json:"scheduledFor"` //* only for unpublished posts, "" turns the post back into a draft
+	Visibility      *string         `json:"visibility"`
 }
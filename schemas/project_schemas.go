@@ -1,6 +1,11 @@
 package schemas
 
-import "github.com/lib/pq"
+import (
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/lib/pq"
+)
 
 type ProjectCreateSchema struct {
 	Title       string         `json:"title" validate:"required,max=20"`
@@ -11,6 +16,7 @@ type ProjectCreateSchema struct {
 	CoverPic    string         `json:"coverPic"`
 	IsPrivate   bool           `json:"isPrivate" validate:"boolean"`
 	Links       pq.StringArray `json:"links" validate:"dive,url"`
+	TemplateID  string         `json:"templateID" validate:"omitempty,uuid"` //* the empty fields are taken from the template
 }
 
 type ProjectUpdateSchema struct {
@@ -24,3 +30,70 @@ type ProjectUpdateSchema struct {
 	Links        pq.StringArray `json:"links" validate:"dive,url"`
 	PrivateLinks pq.StringArray `json:"privateLinks" validate:"dive,url"`
 }
+
+type MilestoneCreateSchema struct {
+	Title       string    `json:"title" validate:"required,max=50"`
+	Description string    `json:"description" validate:"max=500"`
+	DueDate     time.Time `json:"dueDate" validate:"required"`
+}
+
+type MilestoneUpdateSchema struct {
+	Title       string                 `json:"title" validate:"max=50"`
+	Description string                 `json:"description" validate:"max=500"`
+	DueDate     time.Time              `json:"dueDate"`
+	Status      models.MilestoneStatus `json:"status" validate:"omitempty,oneof=planned in_progress completed"`
+}
+
+type ProjectFolderCreateSchema struct {
+	Name     string `json:"name" validate:"required,max=50"`
+	ParentID string `json:"parentID" validate:"omitempty,uuid"`
+}
+
+type ProjectFolderUpdateSchema struct {
+	Name     string  `json:"name" validate:"max=50"`
+	ParentID *string `json:"parentID" validate:"omitempty,uuid"` //* empty to move to the root of the project
+}
+
+type ProjectFileUpdateSchema struct {
+	Name     string  `json:"name" validate:"max=100"`
+	FolderID *string `json:"folderID" validate:"omitempty,uuid"` //* empty to move to the root of the project
+}
+
+type WikiPageCreateSchema struct {
+	Title    string `json:"title" validate:"required,max=100"`
+	Slug     string `json:"slug" validate:"max=100"` //* made from the title if empty
+	Content  string `json:"content" validate:"max=50000"`
+	IsPublic bool   `json:"isPublic"`
+}
+
+type WikiPageUpdateSchema struct {
+	Title    string  `json:"title" validate:"max=100"`
+	Content  *string `json:"content" validate:"omitempty,max=50000"`
+	IsPublic *bool   `json:"isPublic"`
+}
+
+type ProjectRoleCreateSchema struct {
+	Name        string   `json:"name" validate:"required,max=25"`
+	Permissions []string `json:"permissions" validate:"dive,oneof=tasks openings edit_openings applications members chats settings documents delete_documents"`
+}
+
+type ProjectRoleUpdateSchema struct {
+	Name        string   `json:"name" validate:"max=25"`
+	Permissions []string `json:"permissions" validate:"omitempty,dive,oneof=tasks openings edit_openings applications members chats settings documents delete_documents"`
+}
+
+type ProjectTemplateCreateSchema struct {
+	Name  string `json:"name" validate:"required,max=50"`
+	About string `json:"about" validate:"max=500"`
+}
+
+type ProjectJoinRequestCreateSchema struct {
+	Message string `json:"message" validate:"max=500"`
+}
+
+type ProjectInviteLinkCreateSchema struct {
+	Role          models.ProjectRole `json:"role" validate:"required,max=25"`
+	Title         string             `json:"title" validate:"required,max=25"`
+	MaxUses       int                `json:"maxUses" validate:"min=0,max=1000"`               //* 0 for no limit
+	ExpiresInDays int                `json:"expiresInDays" validate:"omitempty,min=1,max=30"` //* a week if empty
+}
@@ -5,6 +5,7 @@ type ReportCreateSchema struct {
 	ReportType  int    `json:"reportType" validate:"required"`
 	UserID      string `json:"userID"`
 	PostID      string `json:"postID"`
+	CommentID   string `json:"commentID"`
 	ProjectID   string `json:"projectID"`
 	EventID     string `json:"eventID"`
 	OpeningID   string `json:"openingID"`
@@ -14,6 +14,7 @@ type TaskCreateSchema struct { // from request
 	Tags        pq.StringArray  `json:"tags"`
 	Users       pq.StringArray  `json:"users" validate:"required"`
 	Priority    models.Priority `json:"priority"`
+	MilestoneID string          `json:"milestoneID" validate:"omitempty,uuid"`
 }
 
 type TaskEditSchema struct { // from request
@@ -1,6 +1,7 @@
 package schemas
 
 import (
+	"github.com/Pratham-Mishra04/interact/models"
 	"github.com/lib/pq"
 )
 
@@ -23,6 +24,10 @@ type UserUpdateSchema struct {
 	Links      *pq.StringArray `json:"links"`
 }
 
+type PrivacySettingsUpdateSchema struct {
+	AllowMentionsFrom models.MentionPrivacy `json:"allowMentionsFrom" validate:"required,oneof=everyone following nobody"`
+}
+
 type ProfileUpdateSchema struct {
 	School      *string         `json:"school" validate:"max=150"`
 	Degree      *string         `json:"degree" validate:"max=25"`
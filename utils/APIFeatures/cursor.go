@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+const maxCursorLimit = 50
+
+// CursorLimit returns the page size asked for in the limit query param.
+func CursorLimit(c *fiber.Ctx) int {
+	limit, err := strconv.Atoi(c.Query("limit", "10"))
+	if err != nil || limit <= 0 {
+		return 10
+	}
+	if limit > maxCursorLimit {
+		return maxCursorLimit
+	}
+	return limit
+}
+
+func EncodeCursor(createdAt time.Time, id uuid.UUID) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d_%s", createdAt.UnixNano(), id)))
+}
+
+func DecodeCursor(cursor string) (time.Time, uuid.UUID, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+
+	nanos, id, found := strings.Cut(string(decoded), "_")
+	if !found {
+		return time.Time{}, uuid.Nil, fmt.Errorf("invalid cursor")
+	}
+
+	parsedNanos, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+
+	parsedID, err := uuid.Parse(id)
+	if err != nil {
+		return time.Time{}, uuid.Nil, err
+	}
+
+	return time.Unix(0, parsedNanos), parsedID, nil
+}
+
+// NextCursor returns the cursor of the page after the one ending at createdAt and id, or "" if this was the last page.
+func NextCursor(count int, limit int, createdAt time.Time, id uuid.UUID) string {
+	if count < limit {
+		return ""
+	}
+	return EncodeCursor(createdAt, id)
+}
+
+// CursorPaginator pages through the rows of table ordered by created_at and id,
+// starting after the row in the cursor query param.
+func CursorPaginator(c *fiber.Ctx, table string, ascending bool) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		comparison, direction := "<", "DESC"
+		if ascending {
+			comparison, direction = ">", "ASC"
+		}
+
+		if cursor := c.Query("cursor", ""); cursor != "" {
+			createdAt, id, err := DecodeCursor(cursor)
+			if err != nil {
+				log.Println("Failed to Paginate due to invalid cursor.")
+			} else {
+				db = db.Where(fmt.Sprintf("(%s.created_at, %s.id) %s (?, ?)", table, table, comparison), createdAt, id)
+			}
+		}
+
+		return db.Order(fmt.Sprintf("%s.created_at %s, %s.id %s", table, direction, table, direction)).Limit(CursorLimit(c))
+	}
+}
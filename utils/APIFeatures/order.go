@@ -1,6 +1,9 @@
 package utils
 
-import "gorm.io/gorm"
+import (
+	"github.com/Pratham-Mishra04/interact/models"
+	"gorm.io/gorm"
+)
 
 type OrderType string
 
@@ -19,8 +22,17 @@ const (
 	Openings ModelType = "openings"
 	Events   ModelType = "events"
 	Posts    ModelType = "posts"
+	Hashtags ModelType = "hashtags"
 )
 
+// TrendingPosts keeps the published posts of active users, ordered by their likes, comments, shares and poll voters, weighted down by their age.
+func TrendingPosts(db *gorm.DB) *gorm.DB {
+	return db.Joins("JOIN users ON posts.user_id = users.id AND users.active = ?", true).
+		Where("posts.status = ?", models.PostPublished).
+		Select("*, posts.id, posts.created_at, (2 * no_likes + no_comments + 5 * no_shares + COALESCE((SELECT polls.no_voters FROM polls WHERE polls.post_id = posts.id), 0)) / (1 + EXTRACT(EPOCH FROM age(NOW(), posts.created_at)) / 3600 / 24 / 7) AS weighted_average"). //! 7 days
+		Order("weighted_average DESC, posts.created_at ASC")
+}
+
 func Order(db *gorm.DB, order OrderType, modelType ModelType) *gorm.DB {
 	switch order {
 	case Latest:
@@ -38,7 +50,7 @@ func Order(db *gorm.DB, order OrderType, modelType ModelType) *gorm.DB {
 	case "", Trending:
 		switch modelType {
 		case Projects:
-			return db.Where("is_private = ?", false).
+			return db.Where("is_private = ? AND is_archived = ?", false, false).
 				Select("*, (total_no_views + 3 * no_likes + 2 * no_comments + 5 * no_shares) AS weighted_average").
 				Order("weighted_average DESC")
 
@@ -52,7 +64,7 @@ func Order(db *gorm.DB, order OrderType, modelType ModelType) *gorm.DB {
 
 		case Openings:
 			return db.Where("openings.active=true").
-				Joins("JOIN openings ON openings.project_id = projects.id AND projects.is_private = ?", false).
+				Joins("JOIN openings ON openings.project_id = projects.id AND projects.is_private = ? AND projects.is_archived = ?", false, false).
 				Select("openings.*, (projects.total_no_views * 0.5 + openings.no_of_applications * 0.3) / (1 + EXTRACT(EPOCH FROM age(NOW(), openings.created_at)) / 3600 / 24 / 15) AS t_ratio").
 				Order("t_ratio DESC")
 
@@ -60,9 +72,15 @@ func Order(db *gorm.DB, order OrderType, modelType ModelType) *gorm.DB {
 			return db.Order(string(modelType) + ".impressions DESC")
 
 		case Posts:
-			return db.Joins("JOIN users ON posts.user_id = users.id AND users.active = ?", true).
-				Select("*, posts.id, posts.created_at, (2 * no_likes + no_comments + 5 * no_shares) / (1 + EXTRACT(EPOCH FROM age(NOW(), posts.created_at)) / 3600 / 24 / 7) AS weighted_average"). //! 7 days
-				Order("weighted_average DESC, posts.created_at ASC")
+			return TrendingPosts(db)
+
+		case Hashtags:
+			return db.Joins("JOIN post_hashtags ON post_hashtags.hashtag_id = hashtags.id").
+				Joins("JOIN posts ON post_hashtags.post_id = posts.id AND posts.status = ? AND posts.created_at > NOW() - INTERVAL '30 days'", models.PostPublished).
+				Joins("JOIN users ON posts.user_id = users.id AND users.active = ?", true).
+				Select("hashtags.*, SUM((1 + 2 * posts.no_likes + posts.no_comments + 5 * posts.no_shares) / (1 + EXTRACT(EPOCH FROM age(NOW(), posts.created_at)) / 3600 / 24 / 2)) AS weighted_average"). //! 2 days
+				Group("hashtags.id").
+				Order("weighted_average DESC, hashtags.no_posts DESC")
 		default:
 			return db.Order(string(modelType) + ".created_at DESC")
 		}
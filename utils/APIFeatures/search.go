@@ -4,59 +4,76 @@ import (
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/lib/pq"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
+// similarityThreshold is how close a name has to be to the search to count as a typo of it
+const similarityThreshold = 0.3
+
+// fullTextSearch keeps the rows matching the search in the full text documents or, for names, close enough to it,
+// and orders them by relevance before any other order added later.
+func fullTextSearch(db *gorm.DB, searchStr string, vectors []string, names []string) *gorm.DB {
+	params := map[string]interface{}{"search": searchStr, "threshold": similarityThreshold}
+
+	var conditions []string
+	for _, vector := range vectors {
+		conditions = append(conditions, vector+" @@ websearch_to_tsquery('english', @search)")
+	}
+	for _, name := range names {
+		conditions = append(conditions, "similarity("+name+", @search) > @threshold")
+	}
+
+	//* order columns cannot take vars (and an order expression is dropped by later orders), so the search is quoted instead
+	quoted := pq.QuoteLiteral(searchStr)
+
+	var ranks []string
+	for i, vector := range vectors {
+		rank := "ts_rank(" + vector + ", websearch_to_tsquery('english', " + quoted + "))"
+		if i > 0 {
+			rank = "0.5 * " + rank //* documents of related tables count less
+		}
+		ranks = append(ranks, rank)
+	}
+	for _, name := range names {
+		ranks = append(ranks, "similarity("+name+", "+quoted+")")
+	}
+
+	return db.Where(strings.Join(conditions, " OR "), params).
+		Order(clause.OrderByColumn{Column: clause.Column{Name: "(" + strings.Join(ranks, " + ") + ")", Raw: true}, Desc: true})
+}
+
 func Search(c *fiber.Ctx, index int) func(db *gorm.DB) *gorm.DB {
 	return func(db *gorm.DB) *gorm.DB {
-		searchStr := c.Query("search", "")
+		searchStr := strings.TrimSpace(c.Query("search", ""))
 		if searchStr == "" {
 			return db
 		}
 
-		searchStr = strings.ToLower(searchStr)
-
-		substrings := strings.Split(searchStr, " ")
-		var regexPatterns []string
-		for _, substring := range substrings {
-			pattern := ".*" + substring + ".*"
-			regexPatterns = append(regexPatterns, pattern)
-		}
-
 		switch index {
 		case 0: //* users
-			// for _, pattern := range regexPatterns {
-			// 	db = db.Or("LOWER(name) ~ ? OR LOWER(username) ~ ? OR ? = ANY (tags)", pattern, pattern, searchStr)
-			// }
-			db = db.Where("LOWER(name) LIKE ? OR LOWER(username) LIKE ? OR ? = ANY (tags)", "%"+searchStr+"%", "%"+searchStr+"%", searchStr)
-			return db
+			return fullTextSearch(db, searchStr, []string{"users.search_vector"}, []string{"users.name", "users.username"})
 		case 1: //* projects
-			// for _, pattern := range regexPatterns {
-			// 	db = db.Or("LOWER(title) ~ ? OR ? = ANY (tags)", pattern, searchStr)
-			// }
-			db = db.Where("LOWER(title) LIKE ? OR ? = ANY (tags)", "%"+searchStr+"%", searchStr)
-			return db
+			return fullTextSearch(db, searchStr, []string{"projects.search_vector"}, nil)
 		case 2: //* posts
-			for _, pattern := range regexPatterns {
-				db = db.Or("LOWER(content) ~ ? OR ? = ANY (tags) ", pattern, searchStr)
-			}
-			return db
+			return fullTextSearch(db, searchStr, []string{"posts.search_vector"}, nil)
 		case 3: //* openings
-			db = db.Joins("JOIN projects ON openings.project_id = projects.id").
-				Where("LOWER(openings.title) LIKE ? OR LOWER(projects.title) LIKE ? OR ? = ANY (openings.tags) OR ? = ANY (projects.tags)",
-					"%"+searchStr+"%", "%"+searchStr+"%", searchStr, searchStr)
-			return db
+			db = db.Joins("JOIN projects ON openings.project_id = projects.id")
+			return fullTextSearch(db, searchStr, []string{"openings.search_vector", "projects.search_vector"}, nil)
 		case 4: //* events
-			db = db.Joins("JOIN organizations ON events.organization_id = organizations.id").
-				Where("LOWER(events.title) LIKE ? OR LOWER(organizations.organization_title) LIKE ? OR ? = ANY (events.tags)",
-					"%"+searchStr+"%", "%"+searchStr+"%", searchStr)
-			return db
+			db = db.Joins("JOIN organizations ON events.organization_id = organizations.id")
+			return fullTextSearch(db, searchStr, []string{"events.search_vector", "organizations.search_vector"}, []string{"organizations.organization_title"})
 		case 5: //* search_queries
-			db = db.Where("LOWER(query) LIKE ?", "%"+searchStr+"%")
+			db = db.Where("LOWER(query) LIKE ?", "%"+strings.ToLower(searchStr)+"%")
 			return db
 		case 6: //* tasks and sub_tasks
-			db = db.Where("LOWER(title) LIKE ?", "%"+searchStr+"%")
+			db = db.Where("LOWER(title) LIKE ?", "%"+strings.ToLower(searchStr)+"%")
 			return db
+		case 7: //* organizational users, organizations have to be joined by the caller
+			return fullTextSearch(db, searchStr, []string{"organizations.search_vector", "users.search_vector"}, []string{"organizations.organization_title", "users.username"})
+		case 8: //* wiki_pages
+			return fullTextSearch(db, searchStr, []string{"wiki_pages.search_vector"}, nil)
 		default:
 			return db
 		}
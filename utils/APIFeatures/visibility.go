@@ -0,0 +1,25 @@
+package utils
+
+import (
+	"github.com/Pratham-Mishra04/interact/models"
+	"gorm.io/gorm"
+)
+
+// VisiblePosts keeps only the posts which viewerID is allowed to see, viewerID is empty for logged out users.
+func VisiblePosts(viewerID string) func(db *gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if viewerID == "" {
+			return db.Where("posts.visibility = ?", models.PostPublic)
+		}
+
+		return db.Where(`posts.visibility = ? OR posts.user_id = ?
+			OR (posts.visibility = ? AND posts.user_id IN (SELECT followed_id FROM follow_followers WHERE follower_id = ?))
+			OR (posts.visibility = ? AND (posts.id IN (SELECT post_id FROM mentions WHERE user_id = ? AND post_id IS NOT NULL) OR posts.id IN (SELECT post_id FROM post_tagged_users WHERE user_id = ?)))
+			OR (posts.visibility = ? AND posts.organization_id IN (SELECT organization_id FROM organization_memberships WHERE user_id = ?))`,
+			models.PostPublic, viewerID,
+			models.PostFollowers, viewerID,
+			models.PostMentioned, viewerID, viewerID,
+			models.PostOrgMembers, viewerID,
+		)
+	}
+}
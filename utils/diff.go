@@ -0,0 +1,108 @@
+package utils
+
+import "regexp"
+
+const maxDiffCells = 1000000
+
+var diffTokenRegex = regexp.MustCompile(`\s+|[^\s]+`)
+
+type DiffChunk struct {
+	Type string `json:"type"` //* equal, insert or delete
+	Text string `json:"text"`
+}
+
+func appendDiffChunk(chunks []DiffChunk, chunkType string, text string) []DiffChunk {
+	if text == "" {
+		return chunks
+	}
+	if len(chunks) > 0 && chunks[len(chunks)-1].Type == chunkType {
+		chunks[len(chunks)-1].Text += text
+		return chunks
+	}
+	return append(chunks, DiffChunk{Type: chunkType, Text: text})
+}
+
+// DiffWords returns the word level changes needed to turn from into to.
+// Joining the equal and delete chunks gives back from, joining the equal and insert chunks gives back to.
+func DiffWords(from string, to string) []DiffChunk {
+	a := diffTokenRegex.FindAllString(from, -1)
+	b := diffTokenRegex.FindAllString(to, -1)
+
+	var prefix, suffix []DiffChunk
+
+	start := 0
+	for start < len(a) && start < len(b) && a[start] == b[start] {
+		prefix = appendDiffChunk(prefix, "equal", a[start])
+		start++
+	}
+
+	endA, endB := len(a), len(b)
+	for endA > start && endB > start && a[endA-1] == b[endB-1] {
+		endA--
+		endB--
+	}
+	for i := endA; i < len(a); i++ {
+		suffix = appendDiffChunk(suffix, "equal", a[i])
+	}
+
+	a, b = a[start:endA], b[start:endB]
+
+	chunks := prefix
+
+	//* too many changes to diff word by word, show it as a full replacement
+	if len(a)*len(b) > maxDiffCells {
+		for _, token := range a {
+			chunks = appendDiffChunk(chunks, "delete", token)
+		}
+		for _, token := range b {
+			chunks = appendDiffChunk(chunks, "insert", token)
+		}
+	} else {
+		//* lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+		lcs := make([][]int, len(a)+1)
+		for i := range lcs {
+			lcs[i] = make([]int, len(b)+1)
+		}
+		for i := len(a) - 1; i >= 0; i-- {
+			for j := len(b) - 1; j >= 0; j-- {
+				if a[i] == b[j] {
+					lcs[i][j] = lcs[i+1][j+1] + 1
+				} else if lcs[i+1][j] >= lcs[i][j+1] {
+					lcs[i][j] = lcs[i+1][j]
+				} else {
+					lcs[i][j] = lcs[i][j+1]
+				}
+			}
+		}
+
+		i, j := 0, 0
+		for i < len(a) && j < len(b) {
+			if a[i] == b[j] {
+				chunks = appendDiffChunk(chunks, "equal", a[i])
+				i++
+				j++
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				chunks = appendDiffChunk(chunks, "delete", a[i])
+				i++
+			} else {
+				chunks = appendDiffChunk(chunks, "insert", b[j])
+				j++
+			}
+		}
+		for ; i < len(a); i++ {
+			chunks = appendDiffChunk(chunks, "delete", a[i])
+		}
+		for ; j < len(b); j++ {
+			chunks = appendDiffChunk(chunks, "insert", b[j])
+		}
+	}
+
+	for _, chunk := range suffix {
+		chunks = appendDiffChunk(chunks, chunk.Type, chunk.Text)
+	}
+
+	if chunks == nil {
+		chunks = []DiffChunk{}
+	}
+	return chunks
+}
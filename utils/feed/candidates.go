@@ -0,0 +1,155 @@
+package feed
+
+import (
+	"math"
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
+	"github.com/google/uuid"
+)
+
+type ItemType string
+
+const (
+	PostItem    ItemType = "post"
+	ProjectItem ItemType = "project"
+	EventItem   ItemType = "event"
+	OpeningItem ItemType = "opening"
+)
+
+// Item is a ranked entry of the feed, the full model is only loaded for the items of the requested page.
+type Item struct {
+	Type       ItemType  `json:"type"`
+	ID         uuid.UUID `json:"id"`
+	AuthorID   uuid.UUID `json:"authorID"`
+	CreatedAt  time.Time `json:"createdAt"`
+	NoLikes    int       `json:"-"`
+	NoComments int       `json:"-"`
+	NoShares   int       `json:"-"`
+	Score      float64   `json:"score"`
+}
+
+const (
+	followedUsers  = "SELECT followed_id FROM follow_followers WHERE follower_id = @user"
+	followedTags   = "SELECT post_id FROM post_hashtags WHERE hashtag_id IN (SELECT hashtag_id FROM hashtag_follows WHERE user_id = @user)"
+	memberOrgs     = "SELECT organization_id FROM organization_memberships WHERE user_id = @user"
+	memberOrgUsers = "SELECT user_id FROM organizations WHERE id IN (" + memberOrgs + ")"
+	memberProjects = "SELECT project_id FROM memberships WHERE user_id = @user UNION SELECT id FROM projects WHERE user_id = @user"
+	projectMates   = "SELECT user_id FROM memberships WHERE project_id IN (" + memberProjects + ") UNION SELECT user_id FROM projects WHERE id IN (" + memberProjects + ")"
+)
+
+// GatherCandidates collects the items created before anchor which can appear in the feed of userID:
+// posts of followed users, followed hashtags, orgs the user belongs to and fellow project members,
+// and project, event and opening cards of followed users and orgs the user belongs to.
+func GatherCandidates(userID uuid.UUID, anchor time.Time) ([]Item, error) {
+	since := anchor.AddDate(0, 0, -config.FEED_CANDIDATE_WINDOW_DAYS)
+	params := map[string]interface{}{"user": userID}
+
+	var posts []Item
+	if err := initializers.DB.Model(&models.Post{}).
+		Select("posts.id, posts.user_id AS author_id, posts.created_at, posts.no_likes, posts.no_comments, posts.no_shares").
+		Joins("JOIN users ON posts.user_id = users.id AND users.active = ?", true).
+		Scopes(API.VisiblePosts(userID.String())).
+		Where("posts.status = ? AND posts.created_at > ? AND posts.created_at <= ?", models.PostPublished, since, anchor).
+		Where("posts.user_id = @user OR posts.user_id IN ("+followedUsers+") OR posts.id IN ("+followedTags+") OR posts.organization_id IN ("+memberOrgs+") OR posts.user_id IN ("+projectMates+")", params).
+		Order("posts.created_at DESC").
+		Limit(config.FEED_MAX_POST_CANDIDATES).
+		Scan(&posts).Error; err != nil {
+		return nil, err
+	}
+
+	var projects []Item
+	if err := initializers.DB.Model(&models.Project{}).
+		Select("projects.id, projects.user_id AS author_id, projects.created_at, projects.no_likes, projects.no_comments, projects.no_shares").
+		Where("projects.is_private = ? AND projects.is_archived = ? AND projects.created_at > ? AND projects.created_at <= ?", false, false, since, anchor).
+		Where("projects.id NOT IN ("+memberProjects+") AND (projects.user_id IN ("+followedUsers+") OR projects.user_id IN ("+memberOrgUsers+"))", params).
+		Order("projects.created_at DESC").
+		Limit(config.FEED_MAX_CARD_CANDIDATES).
+		Scan(&projects).Error; err != nil {
+		return nil, err
+	}
+
+	var events []Item
+	if err := initializers.DB.Model(&models.Event{}).
+		Select("events.id, organizations.user_id AS author_id, events.created_at, events.no_likes, events.no_comments, events.no_shares").
+		Joins("JOIN organizations ON organizations.id = events.organization_id").
+		Where("events.end_time > ? AND events.created_at > ? AND events.created_at <= ?", anchor, since, anchor).
+		Where("events.organization_id IN ("+memberOrgs+") OR organizations.user_id IN ("+followedUsers+")", params).
+		Order("events.created_at DESC").
+		Limit(config.FEED_MAX_CARD_CANDIDATES).
+		Scan(&events).Error; err != nil {
+		return nil, err
+	}
+
+	var openings []Item
+	if err := initializers.DB.Model(&models.Opening{}).
+		Select("openings.id, projects.user_id AS author_id, openings.created_at").
+		Joins("JOIN projects ON projects.id = openings.project_id").
+		Where("openings.active = ? AND projects.is_private = ? AND projects.is_archived = ? AND openings.created_at > ? AND openings.created_at <= ?", true, false, false, since, anchor).
+		Where("openings.project_id NOT IN ("+memberProjects+") AND (projects.user_id IN ("+followedUsers+") OR projects.user_id IN ("+memberOrgUsers+"))", params).
+		Order("openings.created_at DESC").
+		Limit(config.FEED_MAX_CARD_CANDIDATES).
+		Scan(&openings).Error; err != nil {
+		return nil, err
+	}
+
+	items := make([]Item, 0, len(posts)+len(projects)+len(events)+len(openings))
+	for _, group := range []struct {
+		itemType ItemType
+		items    []Item
+	}{{PostItem, posts}, {ProjectItem, projects}, {EventItem, events}, {OpeningItem, openings}} {
+		for _, item := range group.items {
+			item.Type = group.itemType
+			items = append(items, item)
+		}
+	}
+
+	return items, nil
+}
+
+// Affinities scores how close userID is to other users, from the posts and projects they liked and the messages exchanged with them.
+func Affinities(userID uuid.UUID) (map[uuid.UUID]float64, error) {
+	since := time.Now().AddDate(0, 0, -config.FEED_AFFINITY_WINDOW_DAYS)
+
+	type interaction struct {
+		AuthorID uuid.UUID
+		Count    int
+	}
+
+	var likes []interaction
+	if err := initializers.DB.Raw(`SELECT author_id, COUNT(*) AS count FROM (
+			SELECT posts.user_id AS author_id FROM likes JOIN posts ON posts.id = likes.post_id WHERE likes.user_id = @user AND likes.created_at > @since
+			UNION ALL
+			SELECT projects.user_id AS author_id FROM likes JOIN projects ON projects.id = likes.project_id WHERE likes.user_id = @user AND likes.created_at > @since
+		) AS liked GROUP BY author_id`, map[string]interface{}{"user": userID, "since": since}).
+		Scan(&likes).Error; err != nil {
+		return nil, err
+	}
+
+	var messages []interaction
+	if err := initializers.DB.Raw(`SELECT CASE WHEN chats.creating_user_id = @user THEN chats.accepting_user_id ELSE chats.creating_user_id END AS author_id, COUNT(messages.id) AS count
+		FROM chats JOIN messages ON messages.chat_id = chats.id
+		WHERE (chats.creating_user_id = @user OR chats.accepting_user_id = @user) AND messages.created_at > @since
+		GROUP BY 1`, map[string]interface{}{"user": userID, "since": since}).
+		Scan(&messages).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[uuid.UUID]float64)
+	for _, like := range likes {
+		counts[like.AuthorID] += float64(like.Count)
+	}
+	for _, message := range messages {
+		counts[message.AuthorID] += 0.2 * float64(message.Count) //* a like says more than a single message
+	}
+
+	affinities := make(map[uuid.UUID]float64, len(counts))
+	for authorID, count := range counts {
+		affinities[authorID] = math.Log1p(count)
+	}
+
+	return affinities, nil
+}
@@ -0,0 +1,118 @@
+package feed
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/google/uuid"
+)
+
+var typeWeights = map[ItemType]float64{
+	PostItem:    1,
+	ProjectItem: 0.9,
+	EventItem:   0.8,
+	OpeningItem: 0.7,
+}
+
+const (
+	authorRepeatPenalty = 0.7 //* applied once for every item of the same author already placed
+	typeRepeatPenalty   = 0.9 //* applied when the previous item is of the same type
+)
+
+// Score rates an item on its recency relative to anchor, its engagement and the viewer's affinity with its author.
+func Score(item Item, affinity float64, anchor time.Time) float64 {
+	ageHours := math.Max(anchor.Sub(item.CreatedAt).Hours(), 0)
+	recency := math.Pow(0.5, ageHours/config.FEED_RECENCY_HALF_LIFE_HOURS)
+	engagement := math.Log1p(float64(2*item.NoLikes + item.NoComments + 5*item.NoShares))
+
+	return typeWeights[item.Type] * recency * (1 + engagement) * (1 + affinity)
+}
+
+// Rank scores the items and orders them, spreading out items of the same author and of the same type.
+// For the same items, affinities and anchor the order is always the same.
+func Rank(items []Item, affinities map[uuid.UUID]float64, anchor time.Time) []Item {
+	for i := range items {
+		items[i].Score = Score(items[i], affinities[items[i].AuthorID], anchor)
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		if !items[i].CreatedAt.Equal(items[j].CreatedAt) {
+			return items[i].CreatedAt.After(items[j].CreatedAt)
+		}
+		return items[i].ID.String() < items[j].ID.String()
+	})
+
+	return diversify(items)
+}
+
+// diversify greedily picks the item with the best score after penalties, the items must be sorted by score.
+func diversify(items []Item) []Item {
+	ranked := make([]Item, 0, len(items))
+	placed := make([]bool, len(items))
+	authorCount := make(map[uuid.UUID]int)
+
+	for len(ranked) < len(items) {
+		best, bestScore := -1, -1.0
+		for i, item := range items {
+			if placed[i] {
+				continue
+			}
+			if item.Score <= bestScore {
+				break //* penalties only lower scores, no later item can do better
+			}
+
+			score := item.Score * math.Pow(authorRepeatPenalty, float64(authorCount[item.AuthorID]))
+			if len(ranked) > 0 && ranked[len(ranked)-1].Type == item.Type {
+				score *= typeRepeatPenalty
+			}
+
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+
+		placed[best] = true
+		authorCount[items[best].AuthorID]++
+		ranked = append(ranked, items[best])
+	}
+
+	return ranked
+}
+
+// EncodeCursor points to the feed ranked at anchor, starting from offset.
+func EncodeCursor(anchor time.Time, offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d_%d", anchor.UnixNano(), offset)))
+}
+
+func DecodeCursor(cursor string) (time.Time, int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	nanos, offset, found := strings.Cut(string(decoded), "_")
+	if !found {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor")
+	}
+
+	parsedNanos, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	parsedOffset, err := strconv.Atoi(offset)
+	if err != nil || parsedOffset < 0 {
+		return time.Time{}, 0, fmt.Errorf("invalid cursor")
+	}
+
+	return time.Unix(0, parsedNanos), parsedOffset, nil
+}
@@ -0,0 +1,95 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"gorm.io/gorm/clause"
+)
+
+const maxHashtagLength = 50
+
+func isHashtagRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// NormalizeHashtag lowercases a tag and strips the leading #, returning "" if it is not a valid hashtag.
+func NormalizeHashtag(tag string) string {
+	tag = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(tag), "#"))
+
+	runes := []rune(tag)
+	if len(runes) == 0 || len(runes) > maxHashtagLength {
+		return ""
+	}
+
+	hasLetter := false
+	for _, r := range runes {
+		if !isHashtagRune(r) {
+			return ""
+		}
+		if unicode.IsLetter(r) {
+			hasLetter = true
+		}
+	}
+
+	//* #2024 or #1 are not hashtags
+	if !hasLetter {
+		return ""
+	}
+
+	return tag
+}
+
+// ParseHashtags returns the distinct normalized #hashtags in content, in order of appearance.
+func ParseHashtags(content string) []string {
+	runes := []rune(content)
+
+	seen := make(map[string]bool)
+	var hashtags []string
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '#' || (i > 0 && isHashtagRune(runes[i-1])) {
+			continue
+		}
+
+		j := i + 1
+		for j < len(runes) && isHashtagRune(runes[j]) {
+			j++
+		}
+
+		if hashtag := NormalizeHashtag(string(runes[i+1 : j])); hashtag != "" && !seen[hashtag] {
+			seen[hashtag] = true
+			hashtags = append(hashtags, hashtag)
+		}
+		i = j - 1
+	}
+
+	return hashtags
+}
+
+// GetHashtags returns the Hashtag rows for the hashtags in content, creating the missing ones.
+func GetHashtags(content string) ([]models.Hashtag, error) {
+	names := ParseHashtags(content)
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	var newHashtags []models.Hashtag
+	for _, name := range names {
+		newHashtags = append(newHashtags, models.Hashtag{Name: name})
+	}
+
+	if err := initializers.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&newHashtags).Error; err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	var hashtags []models.Hashtag
+	if err := initializers.DB.Where("name IN ?", names).Find(&hashtags).Error; err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	return hashtags, nil
+}
@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"unicode"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+)
+
+const maxUsernameLength = 16
+
+type MentionToken struct {
+	Username string
+	Offset   int
+	Length   int
+}
+
+func isUsernameRune(r rune) bool {
+	return r == '_' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
+
+// ParseMentions returns every @username token in content, with offsets counted in runes.
+// Tokens preceded by a username character (like in emails) are skipped.
+func ParseMentions(content string) []MentionToken {
+	runes := []rune(content)
+
+	var tokens []MentionToken
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' || (i > 0 && isUsernameRune(runes[i-1])) {
+			continue
+		}
+
+		j := i + 1
+		for j < len(runes) && isUsernameRune(runes[j]) {
+			j++
+		}
+
+		if j == i+1 || j-i-1 > maxUsernameLength {
+			i = j - 1
+			continue
+		}
+
+		tokens = append(tokens, MentionToken{
+			Username: string(runes[i+1 : j]),
+			Offset:   i,
+			Length:   j - i,
+		})
+		i = j - 1
+	}
+
+	return tokens
+}
+
+// GetMentions resolves the tokens in content to active users, one Mention per occurrence.
+func GetMentions(content string) ([]models.Mention, error) {
+	tokens := ParseMentions(content)
+	if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	var usernames []string
+	for _, token := range tokens {
+		usernames = append(usernames, token.Username)
+	}
+
+	var users []models.User
+	if err := initializers.DB.Where("username IN ? AND active = ?", usernames, true).Find(&users).Error; err != nil {
+		return nil, helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	usersMap := make(map[string]models.User)
+	for _, user := range users {
+		usersMap[user.Username] = user
+	}
+
+	var mentions []models.Mention
+	for _, token := range tokens {
+		if user, ok := usersMap[token.Username]; ok {
+			mentions = append(mentions, models.Mention{
+				UserID:   user.ID,
+				Username: token.Username,
+				Offset:   token.Offset,
+				Length:   token.Length,
+			})
+		}
+	}
+
+	return mentions, nil
+}
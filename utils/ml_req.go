@@ -1,13 +1,12 @@
 package utils
 
 import (
-	"bytes"
-	"encoding/json"
-	"net/http"
+	"context"
 
 	"github.com/Pratham-Mishra04/interact/config"
 	"github.com/Pratham-Mishra04/interact/helpers"
-	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/utils/mlclient"
+	"github.com/Pratham-Mishra04/interact/utils/recommender"
 )
 
 func MLReq(id string, url string, args ...int) ([]string, error) {
@@ -25,27 +24,72 @@ func MLReq(id string, url string, args ...int) ([]string, error) {
 		page = args[1]
 	}
 
-	URL := initializers.CONFIG.ML_URL + url
-	reqBody, _ := json.Marshal(map[string]any{
-		"id":    id,
-		"limit": limit,
-		"page":  page,
-	})
-	response, err := http.Post(URL, "application/json", bytes.NewBuffer(reqBody))
+	recommendations, err := mlclient.Default.Recommend(context.Background(), url, id, limit, page)
 	if err != nil {
 		return nil, helpers.AppError{Code: 500, Message: config.SERVER_ERROR, Err: err}
 	}
-	defer response.Body.Close()
 
-	var responseBody struct {
-		Recommendations []string `json:"recommendations"`
-		// Define other fields you expect in the response
+	return recommendations, nil
+}
+
+// GetRecommendations asks the ML API for recommendations, using the in-process recommender when the API fails
+// or when it is the engine selected in config.
+func GetRecommendations(id string, url string, args ...int) ([]string, error) {
+	limit, page := 10, 1
+	if len(args) > 0 {
+		limit = args[0]
+	}
+	if len(args) > 1 {
+		page = args[1]
 	}
 
-	decoder := json.NewDecoder(response.Body)
-	if err := decoder.Decode(&responseBody); err != nil {
-		return nil, helpers.AppError{Code: 500, Message: config.SERVER_ERROR, Err: err}
+	if config.RecommendationEngine() == config.ML_ENGINE {
+		recommendations, err := MLReq(id, url, limit, page)
+		if err == nil && len(recommendations) > 0 {
+			return recommendations, nil
+		}
+		if err != nil {
+			helpers.LogServerError("Error Fetching from ML API, using the in-process recommender", err, url)
+		}
+	}
+
+	return recommender.Recommend(id, url, limit, page)
+}
+
+// GetRecommendationsBatch gets the recommendations of id at several endpoints in one round trip to the ML API,
+// the ones it could not give come from the in-process recommender. Results are keyed by endpoint.
+func GetRecommendationsBatch(id string, endpoints []string, limit int, page int) map[string][]string {
+	results := make(map[string][]string, len(endpoints))
+
+	if config.RecommendationEngine() == config.ML_ENGINE {
+		requests := make([]mlclient.BatchRequest, len(endpoints))
+		for i, endpoint := range endpoints {
+			requests[i] = mlclient.BatchRequest{Key: endpoint, Endpoint: endpoint, ID: id, Limit: limit, Page: page}
+		}
+
+		batch, err := mlclient.Default.RecommendBatch(context.Background(), requests)
+		if err != nil {
+			helpers.LogServerError("Error Fetching Batch from ML API, using the in-process recommender", err, config.ML_BATCH)
+		}
+		for endpoint, recommendations := range batch {
+			if len(recommendations) > 0 {
+				results[endpoint] = recommendations
+			}
+		}
+	}
+
+	for _, endpoint := range endpoints {
+		if _, ok := results[endpoint]; ok {
+			continue
+		}
+
+		recommendations, err := recommender.Recommend(id, endpoint, limit, page)
+		if err != nil {
+			helpers.LogServerError("Error Fetching Recommendations", err, endpoint)
+			continue
+		}
+		results[endpoint] = recommendations
 	}
 
-	return responseBody.Recommendations, nil
+	return results
 }
@@ -0,0 +1,281 @@
+package mlclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/initializers"
+)
+
+const maxResponseSize = 1 << 20
+
+var ErrBreakerOpen = errors.New("ML service unavailable, circuit breaker is open")
+
+// StatusError is returned when the ML service answers with a non 2xx status code.
+type StatusError struct {
+	Code int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("ML service responded with status %d", e.Code)
+}
+
+type Options struct {
+	Timeout          time.Duration // per attempt, for recommendations
+	UploadTimeout    time.Duration // per attempt, for requests sending images
+	MaxRetries       int
+	RetryBackoff     time.Duration // doubled on every retry, with jitter
+	BreakerThreshold int           // consecutive failed requests which open the breaker
+	BreakerCooldown  time.Duration
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Timeout:          2 * time.Second,
+		UploadTimeout:    10 * time.Second,
+		MaxRetries:       2,
+		RetryBackoff:     100 * time.Millisecond,
+		BreakerThreshold: 5,
+		BreakerCooldown:  30 * time.Second,
+	}
+}
+
+type Client struct {
+	baseURL func() string
+	http    *http.Client
+	options Options
+	breaker *breaker
+	metrics metrics
+}
+
+func New(baseURL func() string, options Options) *Client {
+	return &Client{
+		baseURL: baseURL,
+		http:    &http.Client{},
+		options: options,
+		breaker: newBreaker(options.BreakerThreshold, options.BreakerCooldown),
+	}
+}
+
+// Default talks to the ML service at ML_URL, the URL is read on every request since the config is loaded after package initialization.
+var Default = New(func() string { return initializers.CONFIG.ML_URL }, DefaultOptions())
+
+func (c *Client) Stats() Stats {
+	stats := c.metrics.snapshot()
+	stats.Breaker = c.breaker.current()
+	return stats
+}
+
+// Recommend returns the ids recommended for id at endpoint, responses are cached by endpoint, id and page.
+func (c *Client) Recommend(ctx context.Context, endpoint string, id string, limit int, page int) ([]string, error) {
+	key := fmt.Sprintf("%s-%s-%d-%d", endpoint, id, limit, page)
+	if recommendations, err := cache.GetMLRecommendations(key); err == nil {
+		c.metrics.update(func(stats *Stats) { stats.CacheHits++ })
+		return recommendations, nil
+	}
+
+	body, err := json.Marshal(map[string]any{
+		"id":    id,
+		"limit": limit,
+		"page":  page,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var response struct {
+		Recommendations []string `json:"recommendations"`
+	}
+	if err := c.do(ctx, endpoint, "application/json", body, c.options.Timeout, &response); err != nil {
+		return nil, err
+	}
+
+	go cache.SetMLRecommendations(key, response.Recommendations)
+
+	return response.Recommendations, nil
+}
+
+type BatchRequest struct {
+	Key      string `json:"key"`
+	Endpoint string `json:"endpoint"`
+	ID       string `json:"id"`
+	Limit    int    `json:"limit"`
+	Page     int    `json:"page"`
+}
+
+// RecommendBatch makes several recommendation requests in one round trip, results are keyed by the key of their request.
+// Cached results are not requested again, and on error the cached ones are still returned.
+func (c *Client) RecommendBatch(ctx context.Context, requests []BatchRequest) (map[string][]string, error) {
+	results := make(map[string][]string, len(requests))
+
+	var missing []BatchRequest
+	for _, request := range requests {
+		key := fmt.Sprintf("%s-%s-%d-%d", request.Endpoint, request.ID, request.Limit, request.Page)
+		if recommendations, err := cache.GetMLRecommendations(key); err == nil {
+			c.metrics.update(func(stats *Stats) { stats.CacheHits++ })
+			results[request.Key] = recommendations
+			continue
+		}
+		missing = append(missing, request)
+	}
+
+	if len(missing) == 0 {
+		return results, nil
+	}
+
+	body, err := json.Marshal(map[string]any{"requests": missing})
+	if err != nil {
+		return results, err
+	}
+
+	var response struct {
+		Results map[string][]string `json:"results"`
+	}
+	if err := c.do(ctx, config.ML_BATCH, "application/json", body, c.options.Timeout, &response); err != nil {
+		return results, err
+	}
+
+	for _, request := range missing {
+		recommendations, ok := response.Results[request.Key]
+		if !ok {
+			continue
+		}
+		results[request.Key] = recommendations
+
+		key := fmt.Sprintf("%s-%s-%d-%d", request.Endpoint, request.ID, request.Limit, request.Page)
+		go cache.SetMLRecommendations(key, recommendations)
+	}
+
+	return results, nil
+}
+
+// ImageBlurHash returns the data URL of a blurred placeholder of the image.
+func (c *Client) ImageBlurHash(ctx context.Context, filename string, image io.Reader) (string, error) {
+	var buffer bytes.Buffer
+	writer := multipart.NewWriter(&buffer)
+
+	fileWriter, err := writer.CreateFormFile("image", filename)
+	if err != nil {
+		return "", err
+	}
+	if _, err := io.Copy(fileWriter, image); err != nil {
+		return "", err
+	}
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+
+	var response struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		DataURL string `json:"data_url"`
+	}
+	if err := c.do(ctx, config.IMAGE_BLUR_HASH, writer.FormDataContentType(), buffer.Bytes(), c.options.UploadTimeout, &response); err != nil {
+		return "", err
+	}
+
+	if response.Status != "success" {
+		return "", fmt.Errorf("error from ML service: %s", response.Message)
+	}
+
+	return response.DataURL, nil
+}
+
+// do posts body to path, retrying failed attempts while the breaker allows requests.
+func (c *Client) do(ctx context.Context, path string, contentType string, body []byte, timeout time.Duration, response interface{}) error {
+	if !c.breaker.allow() {
+		c.metrics.update(func(stats *Stats) { stats.Rejected++ })
+		return ErrBreakerOpen
+	}
+
+	var err error
+	for attempt := 0; attempt <= c.options.MaxRetries; attempt++ {
+		if attempt > 0 {
+			c.metrics.update(func(stats *Stats) { stats.Retries++ })
+
+			select {
+			case <-time.After(c.backoff(attempt)):
+			case <-ctx.Done():
+				c.breaker.failure()
+				return ctx.Err()
+			}
+		}
+
+		start := time.Now()
+		err = c.attempt(ctx, path, contentType, body, timeout, response)
+		c.metrics.attempt(time.Since(start), err)
+
+		if err == nil {
+			c.breaker.success()
+			return nil
+		}
+
+		if !retryable(err) {
+			var statusErr *StatusError
+			if errors.As(err, &statusErr) {
+				c.breaker.success() //* the service is up, the request was refused
+			} else {
+				c.breaker.failure()
+			}
+			return err
+		}
+	}
+
+	c.breaker.failure()
+	return err
+}
+
+func (c *Client) attempt(ctx context.Context, path string, contentType string, body []byte, timeout time.Duration, response interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL()+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", contentType)
+
+	res, err := c.http.Do(request)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		io.Copy(io.Discard, io.LimitReader(res.Body, maxResponseSize))
+		return &StatusError{Code: res.StatusCode}
+	}
+
+	return json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(response)
+}
+
+// backoff waits between half and all of the doubled backoff, so that clients do not retry in lockstep.
+func (c *Client) backoff(attempt int) time.Duration {
+	backoff := c.options.RetryBackoff << (attempt - 1)
+	if backoff <= 0 {
+		return 0
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// retryable reports whether err is a network error, a timeout or a server side failure.
+func retryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.Code >= 500 || statusErr.Code == http.StatusTooManyRequests
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -0,0 +1,58 @@
+package mlclient
+
+import (
+	"sync"
+	"time"
+)
+
+// Stats is a snapshot of the health of the ML client since the server started.
+type Stats struct {
+	Breaker          BreakerState `json:"breaker"`
+	Requests         int64        `json:"requests"`
+	Successes        int64        `json:"successes"`
+	Failures         int64        `json:"failures"`
+	Retries          int64        `json:"retries"`
+	Rejected         int64        `json:"rejected"` //* refused by the open breaker
+	CacheHits        int64        `json:"cacheHits"`
+	AverageLatencyMs float64      `json:"averageLatencyMs"`
+	LastError        string       `json:"lastError,omitempty"`
+	LastErrorAt      *time.Time   `json:"lastErrorAt,omitempty"`
+	LastSuccessAt    *time.Time   `json:"lastSuccessAt,omitempty"`
+}
+
+type metrics struct {
+	mu           sync.Mutex
+	stats        Stats
+	totalLatency time.Duration
+}
+
+func (m *metrics) update(update func(stats *Stats)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	update(&m.stats)
+}
+
+func (m *metrics) attempt(latency time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	m.stats.Requests++
+	m.totalLatency += latency
+	m.stats.AverageLatencyMs = float64(m.totalLatency) / float64(time.Millisecond) / float64(m.stats.Requests)
+
+	if err != nil {
+		m.stats.Failures++
+		m.stats.LastError = err.Error()
+		m.stats.LastErrorAt = &now
+	} else {
+		m.stats.Successes++
+		m.stats.LastSuccessAt = &now
+	}
+}
+
+func (m *metrics) snapshot() Stats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats
+}
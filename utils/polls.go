@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"sort"
+
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/google/uuid"
+)
+
+// PreparePolls fills in the viewer's votes on the polls of posts and hides the results
+// of the polls which the viewer has not voted on yet and which are still open.
+// The polls are copied before being changed, so posts shared with the cache are left as they are.
+func PreparePolls(posts []models.Post, viewerID string) error {
+	var pollIDs []uuid.UUID
+	for _, post := range posts {
+		if post.Poll != nil {
+			pollIDs = append(pollIDs, post.Poll.ID)
+		}
+	}
+	if len(pollIDs) == 0 {
+		return nil
+	}
+
+	votedOptions := make(map[uuid.UUID][]uuid.UUID)
+	if viewerID != "" {
+		var votes []models.PollVote
+		if err := initializers.DB.Where("poll_id IN ? AND user_id = ?", pollIDs, viewerID).Find(&votes).Error; err != nil {
+			return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+		}
+		for _, vote := range votes {
+			votedOptions[vote.PollID] = append(votedOptions[vote.PollID], vote.PollOptionID)
+		}
+	}
+
+	for i, post := range posts {
+		if post.Poll == nil {
+			continue
+		}
+
+		poll := *post.Poll
+		poll.Options = append([]models.PollOption{}, post.Poll.Options...)
+		preparePoll(&poll, post.UserID.String() == viewerID, votedOptions[poll.ID])
+		posts[i].Poll = &poll
+	}
+
+	return nil
+}
+
+func preparePoll(poll *models.Poll, isAuthor bool, votedOptionIDs []uuid.UUID) {
+	sort.Slice(poll.Options, func(i, j int) bool {
+		return poll.Options[i].Position < poll.Options[j].Position
+	})
+
+	poll.VotedOptionIDs = votedOptionIDs
+	if poll.VotedOptionIDs == nil {
+		poll.VotedOptionIDs = []uuid.UUID{}
+	}
+
+	if isAuthor || len(votedOptionIDs) > 0 || poll.IsClosed() {
+		poll.ResultsHidden = false
+		return
+	}
+
+	poll.ResultsHidden = true
+	poll.NoVoters = 0
+	for i := range poll.Options {
+		poll.Options[i].NoVotes = 0
+	}
+}
+
+// PreparePostPoll is PreparePolls for a single post.
+func PreparePostPoll(post *models.Post, viewerID string) error {
+	posts := []models.Post{*post}
+	if err := PreparePolls(posts, viewerID); err != nil {
+		return err
+	}
+	post.Poll = posts[0].Poll
+	return nil
+}
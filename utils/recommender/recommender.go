@@ -0,0 +1,351 @@
+package recommender
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Pratham-Mishra04/interact/cache"
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gorm.io/gorm"
+)
+
+type candidate struct {
+	ID         uuid.UUID
+	AuthorID   uuid.UUID
+	Tags       pq.StringArray
+	Hashtags   pq.StringArray
+	Category   string
+	Popularity int
+}
+
+func (c candidate) terms() Terms {
+	terms := make(Terms)
+	terms.Add(1, c.Tags...)
+	terms.Add(1, c.Hashtags...)
+	terms.AddCategory(1, c.Category)
+	return terms
+}
+
+// engagement is a table of user interactions with the items of a kind, in column.
+type engagement struct {
+	table  string
+	column string
+}
+
+type kind struct {
+	table       string
+	candidates  func(userID uuid.UUID) *gorm.DB // items which can be recommended to userID, uuid.Nil for similar items
+	engagements []engagement
+}
+
+var (
+	posts = kind{
+		table: "posts",
+		candidates: func(userID uuid.UUID) *gorm.DB {
+			return initializers.DB.Model(&models.Post{}).
+				Select("posts.id, posts.user_id AS author_id, posts.tags, ARRAY(SELECT hashtags.name FROM post_hashtags JOIN hashtags ON hashtags.id = post_hashtags.hashtag_id WHERE post_hashtags.post_id = posts.id) AS hashtags, posts.no_likes + posts.no_comments + posts.no_shares AS popularity").
+				Joins("JOIN users ON posts.user_id = users.id AND users.active = ?", true).
+				Where("posts.status = ? AND posts.visibility = ? AND posts.user_id <> ?", models.PostPublished, models.PostPublic, userID).
+				Order("posts.created_at DESC")
+		},
+		engagements: []engagement{{"likes", "post_id"}},
+	}
+	projects = kind{
+		table: "projects",
+		candidates: func(userID uuid.UUID) *gorm.DB {
+			return initializers.DB.Model(&models.Project{}).
+				Select("projects.id, projects.user_id AS author_id, projects.tags, projects.category, projects.total_no_views AS popularity").
+				Where("projects.is_private = ? AND projects.is_archived = ? AND projects.user_id <> ?", false, false, userID).
+				Where("projects.id NOT IN (SELECT project_id FROM memberships WHERE user_id = ?)", userID).
+				Order("projects.created_at DESC")
+		},
+		engagements: []engagement{{"likes", "project_id"}, {"last_viewed_projects", "project_id"}},
+	}
+	openings = kind{
+		table: "openings",
+		candidates: func(userID uuid.UUID) *gorm.DB {
+			return initializers.DB.Model(&models.Opening{}).
+				Select("openings.id, projects.user_id AS author_id, openings.tags, projects.category, openings.no_of_applications AS popularity").
+				Joins("JOIN projects ON projects.id = openings.project_id").
+				Where("openings.active = ? AND projects.is_private = ? AND projects.is_archived = ? AND projects.user_id <> ?", true, false, false, userID).
+				Where("openings.project_id NOT IN (SELECT project_id FROM memberships WHERE user_id = ?)", userID).
+				Order("openings.created_at DESC")
+		},
+		engagements: []engagement{{"last_viewed_openings", "opening_id"}},
+	}
+	events = kind{
+		table: "events",
+		candidates: func(userID uuid.UUID) *gorm.DB {
+			return initializers.DB.Model(&models.Event{}).
+				Select("events.id, organizations.user_id AS author_id, events.tags, events.category, events.no_views AS popularity").
+				Joins("JOIN organizations ON organizations.id = events.organization_id").
+				Where("events.end_time > ?", time.Now()).
+				Order("events.created_at DESC")
+		},
+		engagements: []engagement{{"likes", "event_id"}},
+	}
+)
+
+type scored struct {
+	id    uuid.UUID
+	score float64
+}
+
+// Recommend works like the ML API without it, id is a user for the recommend endpoints and an item for the similar ones.
+// The ranking is cached and pages are served from the cache.
+func Recommend(id string, endpoint string, limit int, page int) ([]string, error) {
+	recommendations, err := cache.GetRecommendations(endpoint, id)
+	if err != nil {
+		parsedID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, err
+		}
+
+		var ranked []scored
+		switch endpoint {
+		case config.POST_RECOMMENDATION:
+			ranked, err = recommendForUser(posts, parsedID)
+		case config.PROJECT_RECOMMENDATION:
+			ranked, err = recommendForUser(projects, parsedID)
+		case config.OPENING_RECOMMENDATION:
+			ranked, err = recommendForUser(openings, parsedID)
+		case config.EVENT_RECOMMENDATION:
+			ranked, err = recommendForUser(events, parsedID)
+		case config.PROJECT_SIMILAR:
+			ranked, err = similarItems(projects, parsedID)
+		case config.OPENING_SIMILAR:
+			ranked, err = similarItems(openings, parsedID)
+		case config.EVENT_SIMILAR:
+			ranked, err = similarItems(events, parsedID)
+		default:
+			return nil, fmt.Errorf("no recommendations for %s", endpoint)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		recommendations = make([]string, 0, len(ranked))
+		for _, item := range ranked {
+			recommendations = append(recommendations, item.id.String())
+		}
+
+		go cache.SetRecommendations(endpoint, id, recommendations)
+	}
+
+	start := (page - 1) * limit
+	if start < 0 || start >= len(recommendations) {
+		return []string{}, nil
+	}
+	end := start + limit
+	if end > len(recommendations) {
+		end = len(recommendations)
+	}
+
+	return recommendations[start:end], nil
+}
+
+func getCandidates(k kind, userID uuid.UUID, excludeID uuid.UUID) ([]candidate, error) {
+	var candidates []candidate
+	if err := k.candidates(userID).
+		Where(k.table+".id <> ?", excludeID).
+		Limit(config.RECOMMENDATION_CANDIDATES).
+		Scan(&candidates).Error; err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func recommendForUser(k kind, userID uuid.UUID) ([]scored, error) {
+	candidates, err := getCandidates(k, userID, uuid.Nil)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := userTerms(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := func(e engagement) string {
+		return fmt.Sprintf("SELECT m.%[2]s FROM %[1]s m WHERE m.user_id = @user AND m.%[2]s IS NOT NULL", e.table, e.column)
+	}
+	coEngaged, err := coEngagement(k, seed, map[string]interface{}{"user": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	follows, err := followAffinities(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]Terms, len(candidates))
+	for i, candidate := range candidates {
+		documents[i] = candidate.terms()
+	}
+	idf := NewIDF(documents)
+	weightedProfile := idf.Weigh(profile)
+
+	ranked := make([]scored, len(candidates))
+	for i, candidate := range candidates {
+		ranked[i] = scored{
+			id: candidate.ID,
+			score: Cosine(weightedProfile, idf.Weigh(documents[i])) +
+				0.5*math.Log1p(float64(coEngaged[candidate.ID])) +
+				follows[candidate.AuthorID] +
+				0.05*math.Log1p(float64(candidate.Popularity)),
+		}
+	}
+
+	return top(ranked), nil
+}
+
+func similarItems(k kind, itemID uuid.UUID) ([]scored, error) {
+	var targets []candidate
+	if err := k.candidates(uuid.Nil).Where(k.table+".id = ?", itemID).Limit(1).Scan(&targets).Error; err != nil {
+		return nil, err
+	}
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no item of id %s", itemID)
+	}
+	target := targets[0].terms()
+
+	candidates, err := getCandidates(k, uuid.Nil, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	seed := func(engagement) string { return "SELECT CAST(@item AS uuid)" }
+	coEngaged, err := coEngagement(k, seed, map[string]interface{}{"user": uuid.Nil, "item": itemID})
+	if err != nil {
+		return nil, err
+	}
+
+	documents := make([]Terms, len(candidates))
+	for i, candidate := range candidates {
+		documents[i] = candidate.terms()
+	}
+	idf := NewIDF(append(documents, target))
+	weightedTarget := idf.Weigh(target)
+
+	ranked := make([]scored, len(candidates))
+	for i, candidate := range candidates {
+		ranked[i] = scored{
+			id: candidate.ID,
+			score: 0.6*Cosine(weightedTarget, idf.Weigh(documents[i])) +
+				0.4*Jaccard(target, documents[i]) +
+				0.5*math.Log1p(float64(coEngaged[candidate.ID])) +
+				0.05*math.Log1p(float64(candidate.Popularity)),
+		}
+	}
+
+	return top(ranked), nil
+}
+
+func top(ranked []scored) []scored {
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].score > ranked[j].score
+	})
+	if len(ranked) > config.RECOMMENDATION_CACHE_SIZE {
+		ranked = ranked[:config.RECOMMENDATION_CACHE_SIZE]
+	}
+	return ranked
+}
+
+// userTerms describes the interests of a user from their tags, areas of collaboration and what they liked and viewed.
+func userTerms(userID uuid.UUID) (Terms, error) {
+	var user models.User
+	if err := initializers.DB.Preload("Profile").First(&user, "id = ?", userID).Error; err != nil {
+		return nil, err
+	}
+
+	terms := make(Terms)
+	terms.Add(2, user.Tags...)
+	terms.Add(1.5, user.Profile.AreasOfCollaboration...)
+
+	var engaged []candidate
+	if err := initializers.DB.Raw(`SELECT * FROM (
+			SELECT posts.tags, '' AS category FROM likes JOIN posts ON posts.id = likes.post_id WHERE likes.user_id = @user
+			UNION ALL
+			SELECT projects.tags, projects.category FROM likes JOIN projects ON projects.id = likes.project_id WHERE likes.user_id = @user
+			UNION ALL
+			SELECT projects.tags, projects.category FROM last_viewed_projects JOIN projects ON projects.id = last_viewed_projects.project_id WHERE last_viewed_projects.user_id = @user
+			UNION ALL
+			SELECT events.tags, events.category FROM likes JOIN events ON events.id = likes.event_id WHERE likes.user_id = @user
+		) AS engaged LIMIT 200`, map[string]interface{}{"user": userID}).
+		Scan(&engaged).Error; err != nil {
+		return nil, err
+	}
+
+	for _, item := range engaged {
+		terms.Add(0.5, item.Tags...)
+		terms.AddCategory(0.5, item.Category)
+	}
+
+	return terms, nil
+}
+
+// coEngagement counts, for every item of the kind, the users who engaged with it and with the seed items too.
+func coEngagement(k kind, seed func(engagement) string, params map[string]interface{}) (map[uuid.UUID]int, error) {
+	counts := make(map[uuid.UUID]int)
+
+	for _, e := range k.engagements {
+		var rows []struct {
+			ID    uuid.UUID
+			Count int
+		}
+
+		query := fmt.Sprintf(`SELECT e.%[2]s AS id, COUNT(*) AS count FROM %[1]s e
+			WHERE e.%[2]s IS NOT NULL AND e.%[2]s NOT IN (%[3]s) AND e.user_id <> @user
+			AND e.user_id IN (SELECT n.user_id FROM %[1]s n WHERE n.%[2]s IN (%[3]s))
+			GROUP BY e.%[2]s`, e.table, e.column, seed(e))
+
+		if err := initializers.DB.Raw(query, params).Scan(&rows).Error; err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			counts[row.ID] += row.Count
+		}
+	}
+
+	return counts, nil
+}
+
+// followAffinities scores the users followed by userID, and the users followed by them.
+func followAffinities(userID uuid.UUID) (map[uuid.UUID]float64, error) {
+	var followedIDs []uuid.UUID
+	if err := initializers.DB.Model(&models.FollowFollower{}).Where("follower_id = ?", userID).Pluck("followed_id", &followedIDs).Error; err != nil {
+		return nil, err
+	}
+
+	var secondDegree []struct {
+		ID    uuid.UUID
+		Count int
+	}
+	if len(followedIDs) > 0 {
+		if err := initializers.DB.Model(&models.FollowFollower{}).
+			Select("followed_id AS id, COUNT(*) AS count").
+			Where("follower_id IN ? AND followed_id <> ?", followedIDs, userID).
+			Group("followed_id").
+			Scan(&secondDegree).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	affinities := make(map[uuid.UUID]float64)
+	for _, follow := range secondDegree {
+		affinities[follow.ID] = 0.3 * math.Log1p(float64(follow.Count))
+	}
+	for _, followedID := range followedIDs {
+		affinities[followedID] = 1
+	}
+
+	return affinities, nil
+}
@@ -0,0 +1,92 @@
+package recommender
+
+import (
+	"math"
+	"strings"
+)
+
+// Terms is a bag of weighted terms describing a user or an item, built from tags and categories.
+type Terms map[string]float64
+
+func (t Terms) Add(weight float64, values ...string) {
+	for _, value := range values {
+		value = strings.ToLower(strings.TrimSpace(value))
+		if value != "" {
+			t[value] += weight
+		}
+	}
+}
+
+func (t Terms) AddCategory(weight float64, category string) {
+	if category != "" {
+		t.Add(weight, "category:"+category)
+	}
+}
+
+// IDF holds the inverse document frequency of the terms of a set of documents, rare terms count more than common ones.
+type IDF struct {
+	weights  map[string]float64
+	fallback float64 //* for terms in none of the documents
+}
+
+func NewIDF(documents []Terms) IDF {
+	frequencies := make(map[string]int)
+	for _, document := range documents {
+		for term := range document {
+			frequencies[term]++
+		}
+	}
+
+	idf := IDF{
+		weights:  make(map[string]float64, len(frequencies)),
+		fallback: math.Log(1 + float64(len(documents))),
+	}
+	for term, frequency := range frequencies {
+		idf.weights[term] = math.Log(1 + float64(len(documents))/float64(1+frequency))
+	}
+	return idf
+}
+
+// Weigh returns the TF-IDF vector of t.
+func (idf IDF) Weigh(t Terms) Terms {
+	weighted := make(Terms, len(t))
+	for term, tf := range t {
+		weight, ok := idf.weights[term]
+		if !ok {
+			weight = idf.fallback
+		}
+		weighted[term] = tf * weight
+	}
+	return weighted
+}
+
+func Cosine(a Terms, b Terms) float64 {
+	var dot, normA, normB float64
+	for term, weight := range a {
+		normA += weight * weight
+		dot += weight * b[term]
+	}
+	for _, weight := range b {
+		normB += weight * weight
+	}
+
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// Jaccard is the share of terms a and b have in common, weights are ignored.
+func Jaccard(a Terms, b Terms) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+
+	common := 0
+	for term := range a {
+		if _, ok := b[term]; ok {
+			common++
+		}
+	}
+	return float64(common) / float64(len(a)+len(b)-common)
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/Pratham-Mishra04/interact/config"
+)
+
+// NormalizeSearchTerm lowercases a search and collapses its whitespace, returning "" if it is empty or too long.
+func NormalizeSearchTerm(term string) string {
+	term = strings.Join(strings.Fields(strings.ToLower(term)), " ")
+	if len([]rune(term)) > config.MAX_SEARCH_TERM_LENGTH {
+		return ""
+	}
+	return term
+}
+
+var sensitiveSearchPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[a-z0-9._%+\-]+@[a-z0-9\-]+(\.[a-z0-9\-]+)*\.[a-z]{2,}`),            //* emails
+	regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`),      //* uuids
+	regexp.MustCompile(`https?://\S*[?#]\S+`),                                               //* links with parameters
+	regexp.MustCompile(`\b(password|passwd|otp|token|secret|api[_\-]?key)\b\s*[:=]?\s*\S+`), //* credentials
+}
+
+// numberPattern matches phone, card and id numbers, and also lists of years which are let through.
+var numberPattern = regexp.MustCompile(`\+?\d(?:[\s\-.()]?\d){6,}`)
+
+var yearPattern = regexp.MustCompile(`^(19|20)\d{2}$`)
+
+// isYearList reports whether a number only has years in it, like "2023 2024" or "2020-2024".
+func isYearList(number string) bool {
+	groups := strings.FieldsFunc(number, func(r rune) bool { return !unicode.IsDigit(r) })
+	for _, group := range groups {
+		if !yearPattern.MatchString(group) {
+			return false
+		}
+	}
+	return len(groups) > 0
+}
+
+// IsSensitiveSearch reports whether a normalized search looks like it holds personal data or secrets,
+// such searches are never stored or shown to others.
+func IsSensitiveSearch(term string) bool {
+	for _, pattern := range sensitiveSearchPatterns {
+		if pattern.MatchString(term) {
+			return true
+		}
+	}
+
+	for _, number := range numberPattern.FindAllString(term, -1) {
+		if !isYearList(number) {
+			return true
+		}
+	}
+
+	//* long random looking words, like tokens and keys
+	for _, word := range strings.Fields(term) {
+		if len(word) < 20 {
+			continue
+		}
+		hasLetter, hasDigit := false, false
+		for _, r := range word {
+			hasLetter = hasLetter || unicode.IsLetter(r)
+			hasDigit = hasDigit || unicode.IsDigit(r)
+		}
+		if hasLetter && hasDigit {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,125 @@
+package unfurl
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// PreviewFetcher is implemented by Fetcher, other implementations can be swapped in where previews are built.
+type PreviewFetcher interface {
+	Fetch(ctx context.Context, rawURL string) (*Preview, error)
+}
+
+type Options struct {
+	Timeout      time.Duration // for the whole request, redirects included
+	MaxBodySize  int64         // bytes of HTML read from the page
+	MaxRedirects int
+	UserAgent    string
+	AllowPrivate bool // only for pointing the fetcher at a local test server, never enable in production
+}
+
+func DefaultOptions() Options {
+	return Options{
+		Timeout:      5 * time.Second,
+		MaxBodySize:  1 << 20,
+		MaxRedirects: 3,
+		UserAgent:    "InteractBot/1.0 (+link previews)",
+	}
+}
+
+type Fetcher struct {
+	client  *http.Client
+	options Options
+}
+
+func NewFetcher(options Options) *Fetcher {
+	dialer := &net.Dialer{
+		Timeout:   options.Timeout,
+		KeepAlive: 30 * time.Second,
+	}
+	if !options.AllowPrivate {
+		dialer.Control = dialControl
+	}
+
+	transport := &http.Transport{
+		Proxy:                 nil, //* a proxy would make the dial guard check the proxy's address instead
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   options.Timeout,
+		ResponseHeaderTimeout: options.Timeout,
+		MaxIdleConns:          20,
+		IdleConnTimeout:       90 * time.Second,
+	}
+
+	client := &http.Client{
+		Transport: transport,
+		Timeout:   options.Timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) > options.MaxRedirects {
+				return fmt.Errorf("unfurl: stopped after %d redirects", options.MaxRedirects)
+			}
+			return checkURL(req.URL, options.AllowPrivate)
+		},
+	}
+
+	return &Fetcher{client: client, options: options}
+}
+
+func checkURL(u *url.URL, allowPrivate bool) error {
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unfurl: unsupported scheme %q", u.Scheme)
+	}
+	if u.Hostname() == "" || u.User != nil {
+		return fmt.Errorf("unfurl: invalid url")
+	}
+	if allowPrivate {
+		return nil
+	}
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		return fmt.Errorf("unfurl: port %s is not allowed", port)
+	}
+	return nil
+}
+
+func (f *Fetcher) Fetch(ctx context.Context, rawURL string) (*Preview, error) {
+	pageURL, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkURL(pageURL, f.options.AllowPrivate); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", f.options.UserAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	response, err := f.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return nil, fmt.Errorf("unfurl: %s responded with %d", pageURL.Host, response.StatusCode)
+	}
+
+	mediaType, _, err := mime.ParseMediaType(response.Header.Get("Content-Type"))
+	if err != nil || (mediaType != "text/html" && mediaType != "application/xhtml+xml") {
+		return nil, fmt.Errorf("unfurl: %s is not an HTML page", pageURL.Host)
+	}
+
+	//* the final URL after redirects, for resolving relative links
+	preview := Parse(io.LimitReader(response.Body, f.options.MaxBodySize), response.Request.URL)
+	preview.URL = rawURL
+
+	return &preview, nil
+}
@@ -0,0 +1,148 @@
+package unfurl
+
+import (
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	maxTitleLength       = 200
+	maxDescriptionLength = 500
+	maxURLLength         = 2048
+)
+
+var urlRegex = regexp.MustCompile(`https?://[^\s<>"'` + "`" + `]+`)
+
+type Preview struct {
+	URL         string `json:"url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Image       string `json:"image"`
+	SiteName    string `json:"siteName"`
+}
+
+func (p *Preview) IsEmpty() bool {
+	return p.Title == "" && p.Description == "" && p.Image == ""
+}
+
+// ExtractURLs returns the distinct http(s) URLs in content, in order of appearance, at most limit of them.
+func ExtractURLs(content string, limit int) []string {
+	seen := make(map[string]bool)
+	var urls []string
+	for _, match := range urlRegex.FindAllString(content, -1) {
+		//* punctuation ending a sentence is not a part of the link
+		match = strings.TrimRight(match, ".,;:!?)]}'\"")
+		if len(match) > maxURLLength || seen[match] {
+			continue
+		}
+		if _, err := url.Parse(match); err != nil {
+			continue
+		}
+
+		seen[match] = true
+		urls = append(urls, match)
+		if len(urls) == limit {
+			break
+		}
+	}
+	return urls
+}
+
+func truncate(value string, length int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	if utf8.RuneCountInString(value) <= length {
+		return value
+	}
+	return string([]rune(value)[:length-1]) + "…"
+}
+
+// Parse reads the OpenGraph and Twitter card metadata from the head of an HTML page,
+// falling back to the <title> and description meta tags. pageURL is used to resolve relative image links.
+func Parse(body io.Reader, pageURL *url.URL) Preview {
+	meta := make(map[string]string)
+	var title string
+
+	tokenizer := html.NewTokenizer(body)
+	inTitle := false
+
+parsing:
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			break parsing
+
+		case html.StartTagToken, html.SelfClosingTagToken:
+			token := tokenizer.Token()
+			switch token.DataAtom {
+			case atom.Body:
+				break parsing
+			case atom.Title:
+				inTitle = true
+			case atom.Meta:
+				var key, content string
+				for _, attr := range token.Attr {
+					switch strings.ToLower(attr.Key) {
+					case "property", "name":
+						key = strings.ToLower(strings.TrimSpace(attr.Val))
+					case "content":
+						content = strings.TrimSpace(attr.Val)
+					}
+				}
+				if key != "" && content != "" {
+					if _, ok := meta[key]; !ok {
+						meta[key] = content
+					}
+				}
+			}
+
+		case html.TextToken:
+			if inTitle && title == "" {
+				title = string(tokenizer.Text())
+			}
+
+		case html.EndTagToken:
+			token := tokenizer.Token()
+			if token.DataAtom == atom.Title {
+				inTitle = false
+			} else if token.DataAtom == atom.Head {
+				break parsing
+			}
+		}
+	}
+
+	first := func(keys ...string) string {
+		for _, key := range keys {
+			if value := meta[key]; value != "" {
+				return value
+			}
+		}
+		return ""
+	}
+
+	preview := Preview{
+		URL:         pageURL.String(),
+		Title:       truncate(first("og:title", "twitter:title"), maxTitleLength),
+		Description: truncate(first("og:description", "twitter:description", "description"), maxDescriptionLength),
+		SiteName:    truncate(first("og:site_name", "application-name"), maxTitleLength),
+	}
+	if preview.Title == "" {
+		preview.Title = truncate(title, maxTitleLength)
+	}
+	if preview.SiteName == "" {
+		preview.SiteName = pageURL.Hostname()
+	}
+
+	if image := first("og:image:secure_url", "og:image", "twitter:image", "twitter:image:src"); image != "" {
+		if imageURL, err := pageURL.Parse(image); err == nil && (imageURL.Scheme == "http" || imageURL.Scheme == "https") && len(imageURL.String()) <= maxURLLength {
+			preview.Image = imageURL.String()
+		}
+	}
+
+	return preview
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"time"
 
 	"github.com/Pratham-Mishra04/interact/helpers"
@@ -74,3 +75,26 @@ func UploadResume(c *fiber.Ctx) (string, error) {
 
 	return filePath, nil
 }
+
+func UploadProjectFile(c *fiber.Ctx, file *multipart.FileHeader, projectID string) (string, error) {
+	fileContent, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer fileContent.Close()
+
+	var buffer bytes.Buffer
+	if _, err := io.Copy(&buffer, fileContent); err != nil {
+		return "", err
+	}
+
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	filePath := fmt.Sprintf("%s/%s-%s-%s", projectID, c.GetRespHeader("loggedInUserID"), file.Filename, timestamp)
+
+	err = helpers.ProjectFileClient.UploadBucketFile(&buffer, filePath)
+	if err != nil {
+		return "", err
+	}
+
+	return filePath, nil
+}
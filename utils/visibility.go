@@ -0,0 +1,46 @@
+package utils
+
+import (
+	"github.com/Pratham-Mishra04/interact/config"
+	"github.com/Pratham-Mishra04/interact/helpers"
+	"github.com/Pratham-Mishra04/interact/initializers"
+	"github.com/Pratham-Mishra04/interact/models"
+	API "github.com/Pratham-Mishra04/interact/utils/APIFeatures"
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// CheckPostVisibility returns an error if viewerID is not allowed to see the post.
+// Hidden posts are reported as missing, so that their existence is not revealed.
+func CheckPostVisibility(post *models.Post, viewerID string) error {
+	if post.Visibility == models.PostPublic || post.Visibility == "" || post.UserID.String() == viewerID {
+		return nil
+	}
+
+	var count int64
+	if err := initializers.DB.Model(&models.Post{}).Scopes(API.VisiblePosts(viewerID)).Where("posts.id = ?", post.ID).Count(&count).Error; err != nil {
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+	if count == 0 {
+		return &fiber.Error{Code: 400, Message: "No Post of this ID found."}
+	}
+
+	return nil
+}
+
+// CheckPostIDVisibility is CheckPostVisibility for when only the ID of the post is known.
+func CheckPostIDVisibility(postID string, viewerID string) error {
+	var post models.Post
+	if err := initializers.DB.Select("id", "user_id", "visibility", "status").First(&post, "id = ?", postID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return &fiber.Error{Code: 400, Message: "No Post of this ID found."}
+		}
+		return helpers.AppError{Code: 500, Message: config.DATABASE_ERROR, LogMessage: err.Error(), Err: err}
+	}
+
+	if post.Status != models.PostPublished && post.UserID.String() != viewerID {
+		return &fiber.Error{Code: 400, Message: "No Post of this ID found."}
+	}
+
+	return CheckPostVisibility(&post, viewerID)
+}
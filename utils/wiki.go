@@ -0,0 +1,78 @@
+package utils
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/gosimple/slug"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// wikiLinkRegex matches the internal links of wiki pages, [[slug]] or [[slug|label]]
+var wikiLinkRegex = regexp.MustCompile(`\[\[([^\[\]|\n]+)(?:\|([^\[\]\n]+))?\]\]`)
+
+// unsafeAutoLinks turns autolinks with a dangerous scheme, like <javascript:...>, into plain text.
+// The renderer only checks the schemes of links and images, autolinks are written out as they are.
+type unsafeAutoLinks struct{}
+
+func (unsafeAutoLinks) Transform(doc *ast.Document, reader text.Reader, _ parser.Context) {
+	source := reader.Source()
+
+	var links []*ast.AutoLink
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if link, ok := node.(*ast.AutoLink); ok && entering && html.IsDangerousURL(link.URL(source)) {
+			links = append(links, link)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, link := range links {
+		link.Parent().ReplaceChild(link.Parent(), link, ast.NewString(link.Label(source)))
+	}
+}
+
+// wikiMarkdown leaves out raw HTML and dangerous link schemes, since it is not set to render unsafe content.
+var wikiMarkdown = goldmark.New(
+	goldmark.WithExtensions(extension.GFM),
+	goldmark.WithParserOptions(parser.WithASTTransformers(util.Prioritized(unsafeAutoLinks{}, 100))),
+)
+
+// ParseWikiLinks returns the slugs of the pages linked in the content, without duplicates.
+func ParseWikiLinks(content string) []string {
+	links := []string{}
+	seen := map[string]bool{}
+	for _, match := range wikiLinkRegex.FindAllStringSubmatch(content, -1) {
+		pageSlug := slug.Make(match[1])
+		if pageSlug != "" && !seen[pageSlug] {
+			seen[pageSlug] = true
+			links = append(links, pageSlug)
+		}
+	}
+	return links
+}
+
+// RenderWiki converts the markdown of a wiki page to sanitized HTML, internal links point to the sibling page of the slug.
+func RenderWiki(content string) (string, error) {
+	content = wikiLinkRegex.ReplaceAllStringFunc(content, func(link string) string {
+		match := wikiLinkRegex.FindStringSubmatch(link)
+		label := strings.TrimSpace(match[2])
+		if label == "" {
+			label = strings.TrimSpace(match[1])
+		}
+		label = strings.NewReplacer("[", `\[`, "]", `\]`).Replace(label)
+		return "[" + label + "](" + slug.Make(match[1]) + ")"
+	})
+
+	var buffer bytes.Buffer
+	if err := wikiMarkdown.Convert([]byte(content), &buffer); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderWikiStripsRawHTML(t *testing.T) {
+	inputs := []string{
+		"<script>alert(1)</script>",
+		"before <img src=x onerror=alert(1)> after",
+		"<iframe src=\"https://example.com\"></iframe>",
+		"text with <a href=\"https://example.com\" onclick=\"alert(1)\">a link</a>",
+		"[[page|<b onmouseover=alert(1)>label</b>]]",
+	}
+
+	for _, input := range inputs {
+		html, err := RenderWiki(input)
+		if err != nil {
+			t.Fatalf("RenderWiki(%q) error = %v", input, err)
+		}
+		for _, unsafe := range []string{"<script", "<img", "<iframe", "onerror", "onclick", "onmouseover", "<b "} {
+			if strings.Contains(html, unsafe) {
+				t.Errorf("RenderWiki(%q) = %q, want %q left out", input, html, unsafe)
+			}
+		}
+	}
+}
+
+func TestRenderWikiDropsDangerousLinks(t *testing.T) {
+	inputs := []string{
+		"[click](javascript:alert(1))",
+		"[click](JavaScript:alert(1))",
+		"[click](vbscript:msgbox(1))",
+		"[click](data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==)",
+		"<javascript:alert(1)>",
+		"![image](javascript:alert(1))",
+	}
+
+	for _, input := range inputs {
+		html, err := RenderWiki(input)
+		if err != nil {
+			t.Fatalf("RenderWiki(%q) error = %v", input, err)
+		}
+		lower := strings.ToLower(html)
+		for _, scheme := range []string{"javascript:", "vbscript:", "data:text/html"} {
+			if strings.Contains(lower, `href="`+scheme) || strings.Contains(lower, `src="`+scheme) {
+				t.Errorf("RenderWiki(%q) = %q, want the %s link dropped", input, html, scheme)
+			}
+		}
+	}
+}
+
+func TestRenderWikiKeepsSafeContent(t *testing.T) {
+	html, err := RenderWiki("# Title\n\n[docs](https://example.com/docs) and [[Getting Started|the guide]]")
+	if err != nil {
+		t.Fatalf("RenderWiki error = %v", err)
+	}
+
+	for _, want := range []string{"<h1>Title</h1>", `<a href="https://example.com/docs">docs</a>`, `<a href="getting-started">the guide</a>`} {
+		if !strings.Contains(html, want) {
+			t.Errorf("RenderWiki = %q, want it to contain %q", html, want)
+		}
+	}
+}